@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// tableCache is an on-disk cache of previously rendered per-table SQL
+// fragments, keyed by a hash of the table definition plus the dialect
+// used to render it. It lets repeated runs over a schema that's mid
+// development skip regenerating SQL for tables that haven't changed.
+type tableCache struct {
+	dir string
+}
+
+// defaultCacheDir returns "~/.cache/ndm2sql", the default --cache-dir.
+func defaultCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", "ndm2sql"), nil
+}
+
+// newTableCache opens (creating if necessary) a tableCache rooted at dir.
+func newTableCache(dir string) (*tableCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &tableCache{dir: dir}, nil
+}
+
+// tableCacheKey hashes table's canonicalized JSON together with the
+// dialect name, so a table's cache entry is invalidated by any change to
+// its definition or by switching --dialect.
+func tableCacheKey(table Table, dialectName string) (string, error) {
+	canonical, err := json.Marshal(table)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write(canonical)
+	hash.Write([]byte{0})
+	hash.Write([]byte(dialectName))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *tableCache) path(key string) string {
+	return filepath.Join(c.dir, key+".sql")
+}
+
+// get returns the cached SQL fragment for key, if present.
+func (c *tableCache) get(key string) (string, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// put stores sql as the cached fragment for key.
+func (c *tableCache) put(key, sql string) error {
+	return os.WriteFile(c.path(key), []byte(sql), 0644)
+}
@@ -0,0 +1,118 @@
+// Package dialect owns every piece of SQL syntax that varies between
+// database engines, so the generator and diff engine in sibling packages
+// can stay engine-agnostic.
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// Dialect owns every piece of SQL syntax that varies between database
+// engines: identifier quoting, type mapping, default-value rendering and
+// auto-increment semantics. Callers delegate all such decisions to the
+// selected Dialect so their own generation logic stays engine-agnostic.
+type Dialect interface {
+	// Name is the identifier used on the --dialect flag (e.g. "mysql").
+	Name() string
+
+	// QuoteIdentifier wraps a table or column name in the engine's
+	// quoting style (backticks, double quotes, square brackets, ...).
+	QuoteIdentifier(name string) string
+
+	// ColumnType renders the SQL type for a field, applying the engine's
+	// own length/precision rules. isPrimaryKey is true when field is the
+	// table's sole primary-key field, letting dialects that express
+	// auto-increment at the type level (Postgres's SERIAL/BIGSERIAL)
+	// substitute it here instead of via AutoIncrementClause.
+	ColumnType(field schema.TableField, isPrimaryKey bool) string
+
+	// RenderDefault renders the DEFAULT clause value for a field given
+	// its DefaultType ("Literal", "Expression" or "Function").
+	RenderDefault(field schema.TableField) string
+
+	// AutoIncrementClause returns the column-level clause used to mark a
+	// field as auto-incrementing (empty string if the engine expresses
+	// this some other way, e.g. via a SERIAL type).
+	AutoIncrementClause() string
+
+	// SupportsAlterConstraints reports whether this engine can add
+	// constraints (primary keys, foreign keys) to an existing table via
+	// ALTER TABLE ... ADD CONSTRAINT. SQLite cannot: every constraint
+	// must be declared inline in the original CREATE TABLE, so callers
+	// fall back to inline PRIMARY KEY/FOREIGN KEY clauses when this is
+	// false instead of emitting a separate ALTER TABLE statement.
+	SupportsAlterConstraints() bool
+
+	// ComputedColumnClause wraps expr (already rendered to this dialect's
+	// SQL by cmd/expr) in the column-level clause that declares it a
+	// computed/generated column.
+	ComputedColumnClause(expr string) string
+
+	// FunctionName rewrites a whitelisted expression function name
+	// (SUM, NOW, ...) to this dialect's spelling of it.
+	FunctionName(name string) string
+
+	// RenameColumnClause renders the statement that renames oldName to
+	// field.Name on tableName, in this engine's own syntax (MSSQL has no
+	// RENAME COLUMN and uses the sp_rename stored procedure instead).
+	RenameColumnClause(tableName, oldName string, field schema.TableField) string
+
+	// ModifyColumnClause renders the statement that changes an existing
+	// column's definition to match field, in this engine's own syntax.
+	// Returns "" if the engine can't alter a column's type in place at
+	// all (SQLite, which would require rebuilding the table).
+	ModifyColumnClause(tableName string, field schema.TableField) string
+}
+
+// columnTypeAndModifiers renders "TYPE [NOT NULL] [DEFAULT x]" for field
+// using d's own ColumnType/RenderDefault, the shared tail used by several
+// dialects' RenameColumnClause/ModifyColumnClause implementations.
+func columnTypeAndModifiers(d Dialect, field schema.TableField) string {
+	sql := d.ColumnType(field, false)
+	if !field.IsNullable {
+		sql += " NOT NULL"
+	}
+	if field.DefaultType != "None" {
+		sql += " DEFAULT " + d.RenderDefault(field)
+	}
+	return sql
+}
+
+// byName holds every registered Dialect, keyed by its --dialect flag name.
+var byName = map[string]Dialect{
+	"mysql":    mysqlDialect{},
+	"postgres": postgresDialect{},
+	"mssql":    mssqlDialect{},
+	"sqlite":   sqliteDialect{},
+	"oracle":   oracleDialect{},
+}
+
+// DefaultName is the dialect used when --dialect is not provided.
+const DefaultName = "mysql"
+
+// Resolve looks up a Dialect by name, returning an error that lists the
+// valid options when the name is unknown.
+func Resolve(name string) (Dialect, error) {
+	if name == "" {
+		name = DefaultName
+	}
+	dialect, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown dialect %q (valid: mysql, postgres, mssql, sqlite, oracle)", name)
+	}
+	return dialect, nil
+}
+
+// renderLength renders a "(length)" or "(length,decimals)" suffix for
+// types that carry precision, honoring the NDM2 sentinel for "unset".
+func renderLength(field schema.TableField) string {
+	if field.Length == -2147483648 {
+		return ""
+	}
+	if field.Decimals == -2147483648 {
+		return fmt.Sprintf("(%d)", field.Length)
+	}
+	return fmt.Sprintf("(%d,%d)", field.Length, field.Decimals)
+}
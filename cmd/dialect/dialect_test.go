@@ -0,0 +1,108 @@
+package dialect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+func TestResolveUnknownDialect(t *testing.T) {
+	if _, err := Resolve("db2"); err == nil {
+		t.Fatal("expected an error for an unknown dialect name")
+	}
+}
+
+func TestPostgresSoleIntegerPrimaryKeyUsesSerial(t *testing.T) {
+	d, err := Resolve("postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.ColumnType(schema.TableField{Type: "int"}, true); got != "SERIAL" {
+		t.Fatalf("expected SERIAL for a sole int primary key, got %q", got)
+	}
+	if got := d.ColumnType(schema.TableField{Type: "bigint"}, true); got != "BIGSERIAL" {
+		t.Fatalf("expected BIGSERIAL for a sole bigint primary key, got %q", got)
+	}
+	if got := d.ColumnType(schema.TableField{Type: "int"}, false); got != "INTEGER" {
+		t.Fatalf("expected a non-primary-key int column to stay INTEGER, got %q", got)
+	}
+}
+
+func TestSQLiteDoesNotSupportAlterConstraints(t *testing.T) {
+	d, err := Resolve("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.SupportsAlterConstraints() {
+		t.Fatal("expected sqlite to report it can't ALTER TABLE ... ADD CONSTRAINT")
+	}
+	if got := d.ModifyColumnClause("t", schema.TableField{Name: "c", Type: "int"}); got != "" {
+		t.Fatalf("expected an empty ModifyColumnClause for sqlite, got %q", got)
+	}
+}
+
+func TestModifyColumnClauseCarriesNullabilityAndDefault(t *testing.T) {
+	field := schema.TableField{Name: "name", Type: "varchar", Length: 50, IsNullable: false, DefaultType: "Expression", DefaultValue: "unknown"}
+
+	cases := map[string][]string{
+		"postgres": {"SET NOT NULL", "SET DEFAULT 'unknown'"},
+		"mssql":    {"NOT NULL", "ADD CONSTRAINT", "DEFAULT 'unknown'"},
+		"oracle":   {"NOT NULL", "DEFAULT 'unknown'"},
+		"mysql":    {"NOT NULL", "DEFAULT 'unknown'"},
+	}
+	for name, wants := range cases {
+		d, err := Resolve(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := d.ModifyColumnClause("t", field)
+		for _, want := range wants {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: expected ModifyColumnClause to contain %q, got %q", name, want, got)
+			}
+		}
+	}
+}
+
+func TestModifyColumnClauseDropsDefaultWhenFieldHasNone(t *testing.T) {
+	field := schema.TableField{Name: "name", Type: "varchar", Length: 50, IsNullable: true, DefaultType: "None"}
+
+	d, err := Resolve("postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := d.ModifyColumnClause("t", field)
+	if !strings.Contains(got, "DROP NOT NULL") || !strings.Contains(got, "DROP DEFAULT") {
+		t.Fatalf("expected DROP NOT NULL and DROP DEFAULT, got %q", got)
+	}
+
+	dMssql, err := Resolve("mssql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotMssql := dMssql.ModifyColumnClause("t", field)
+	if !strings.Contains(gotMssql, "DROP CONSTRAINT IF EXISTS") || strings.Contains(gotMssql, "ADD CONSTRAINT") {
+		t.Fatalf("expected mssql to only drop the default constraint, got %q", gotMssql)
+	}
+}
+
+func TestRenameColumnClausePerDialect(t *testing.T) {
+	field := schema.TableField{Name: "new_name", Type: "varchar", Length: 32, DefaultType: "None"}
+	cases := map[string]string{
+		"mysql":    "CHANGE COLUMN",
+		"postgres": "RENAME COLUMN",
+		"mssql":    "sp_rename",
+		"sqlite":   "RENAME COLUMN",
+		"oracle":   "RENAME COLUMN",
+	}
+	for name, want := range cases {
+		d, err := Resolve(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.RenameColumnClause("t", "old_name", field); !strings.Contains(got, want) {
+			t.Errorf("%s: expected RenameColumnClause to contain %q, got %q", name, want, got)
+		}
+	}
+}
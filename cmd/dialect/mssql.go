@@ -0,0 +1,95 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// mssqlDialect targets Microsoft SQL Server: bracket-quoted identifiers,
+// BIT for booleans and IDENTITY(1,1) for surrogate keys.
+type mssqlDialect struct{}
+
+func (mssqlDialect) Name() string { return "mssql" }
+
+func (mssqlDialect) QuoteIdentifier(name string) string {
+	return fmt.Sprintf("[%s]", name)
+}
+
+func (mssqlDialect) ColumnType(field schema.TableField, isPrimaryKey bool) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer":
+		return "INT"
+	case "bool", "boolean":
+		return "BIT"
+	case "decimal":
+		return "DECIMAL" + renderLength(field)
+	case "varchar", "string":
+		return "NVARCHAR" + renderLength(field)
+	case "text":
+		return "NVARCHAR(MAX)"
+	case "datetime":
+		return "DATETIME2"
+	case "date":
+		return "DATE"
+	default:
+		return strings.ToUpper(field.Type) + renderLength(field)
+	}
+}
+
+func (mssqlDialect) RenderDefault(field schema.TableField) string {
+	switch field.DefaultType {
+	case "Function":
+		return field.DefaultValue
+	case "Expression":
+		return fmt.Sprintf("'%s'", field.DefaultValue)
+	default:
+		return field.DefaultValue
+	}
+}
+
+func (mssqlDialect) AutoIncrementClause() string {
+	return "IDENTITY(1,1)"
+}
+
+func (mssqlDialect) SupportsAlterConstraints() bool {
+	return true
+}
+
+func (mssqlDialect) ComputedColumnClause(expr string) string {
+	return fmt.Sprintf("AS (%s) PERSISTED", expr)
+}
+
+func (mssqlDialect) FunctionName(name string) string {
+	if name == "NOW" {
+		return "GETDATE"
+	}
+	return name
+}
+
+// RenameColumnClause uses sp_rename: MSSQL has no RENAME COLUMN syntax.
+func (mssqlDialect) RenameColumnClause(tableName, oldName string, field schema.TableField) string {
+	return fmt.Sprintf("EXEC sp_rename '%s.%s', '%s', 'COLUMN';\n\n", tableName, oldName, field.Name)
+}
+
+// ModifyColumnClause omits "TYPE" (that's Postgres syntax): ALTER COLUMN's
+// type/NOT NULL form covers the type and nullability change directly, but
+// MSSQL defaults are separate named constraints, so the default change is
+// a DROP CONSTRAINT IF EXISTS/ADD CONSTRAINT pair against a constraint
+// name derived the same way as the generator's pk_/idx_fk_ names.
+func (d mssqlDialect) ModifyColumnClause(tableName string, field schema.TableField) string {
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "ALTER TABLE %s ALTER COLUMN %s %s", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name), d.ColumnType(field, false))
+	if !field.IsNullable {
+		sql.WriteString(" NOT NULL")
+	}
+	sql.WriteString(";\n\n")
+
+	defaultConstraint := d.QuoteIdentifier(fmt.Sprintf("df_%s_%s", tableName, field.Name))
+	fmt.Fprintf(&sql, "ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;\n\n", d.QuoteIdentifier(tableName), defaultConstraint)
+	if field.DefaultType != "None" {
+		fmt.Fprintf(&sql, "ALTER TABLE %s ADD CONSTRAINT %s DEFAULT %s FOR %s;\n\n", d.QuoteIdentifier(tableName), defaultConstraint, d.RenderDefault(field), d.QuoteIdentifier(field.Name))
+	}
+	return sql.String()
+}
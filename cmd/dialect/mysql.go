@@ -0,0 +1,77 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// mysqlDialect targets MySQL/MariaDB: backtick-quoted identifiers,
+// AUTO_INCREMENT for surrogate keys and TINYINT(1) for booleans.
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) QuoteIdentifier(name string) string {
+	return fmt.Sprintf("`%s`", name)
+}
+
+func (mysqlDialect) ColumnType(field schema.TableField, isPrimaryKey bool) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer":
+		return "INT"
+	case "bool", "boolean":
+		return "TINYINT(1)"
+	case "decimal":
+		return "DECIMAL" + renderLength(field)
+	case "varchar", "string":
+		return "VARCHAR" + renderLength(field)
+	case "text":
+		return "TEXT"
+	case "datetime":
+		return "DATETIME"
+	case "date":
+		return "DATE"
+	default:
+		return strings.ToUpper(field.Type) + renderLength(field)
+	}
+}
+
+func (mysqlDialect) RenderDefault(field schema.TableField) string {
+	switch field.DefaultType {
+	case "Function":
+		return field.DefaultValue
+	case "Expression":
+		return fmt.Sprintf("'%s'", field.DefaultValue)
+	default:
+		return field.DefaultValue
+	}
+}
+
+func (mysqlDialect) AutoIncrementClause() string {
+	return "AUTO_INCREMENT"
+}
+
+func (mysqlDialect) SupportsAlterConstraints() bool {
+	return true
+}
+
+func (mysqlDialect) ComputedColumnClause(expr string) string {
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", expr)
+}
+
+func (mysqlDialect) FunctionName(name string) string {
+	return name
+}
+
+// RenameColumnClause uses CHANGE COLUMN rather than RENAME COLUMN (MySQL
+// 8+ only) so a rename works against older MySQL/MariaDB too; CHANGE
+// COLUMN requires the column's full definition either way.
+func (d mysqlDialect) RenameColumnClause(tableName, oldName string, field schema.TableField) string {
+	return fmt.Sprintf("ALTER TABLE %s CHANGE COLUMN %s %s %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(oldName), d.QuoteIdentifier(field.Name), columnTypeAndModifiers(d, field))
+}
+
+func (d mysqlDialect) ModifyColumnClause(tableName string, field schema.TableField) string {
+	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name), columnTypeAndModifiers(d, field))
+}
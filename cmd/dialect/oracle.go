@@ -0,0 +1,83 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// oracleDialect targets Oracle Database: double-quoted identifiers,
+// NUMBER-based typing and no AUTO_INCREMENT column clause (surrogate keys
+// are driven by sequences/identity columns declared separately).
+type oracleDialect struct{}
+
+func (oracleDialect) Name() string { return "oracle" }
+
+func (oracleDialect) QuoteIdentifier(name string) string {
+	return fmt.Sprintf("%q", name)
+}
+
+func (oracleDialect) ColumnType(field schema.TableField, isPrimaryKey bool) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer":
+		return "NUMBER(10)"
+	case "bool", "boolean":
+		return "NUMBER(1)"
+	case "decimal":
+		return "NUMBER" + renderLength(field)
+	case "varchar", "string":
+		return "VARCHAR2" + renderLength(field)
+	case "text":
+		return "CLOB"
+	case "datetime":
+		return "TIMESTAMP"
+	case "date":
+		return "DATE"
+	default:
+		return strings.ToUpper(field.Type) + renderLength(field)
+	}
+}
+
+func (oracleDialect) RenderDefault(field schema.TableField) string {
+	switch field.DefaultType {
+	case "Function":
+		return field.DefaultValue
+	case "Expression":
+		return fmt.Sprintf("'%s'", field.DefaultValue)
+	default:
+		return field.DefaultValue
+	}
+}
+
+func (oracleDialect) AutoIncrementClause() string {
+	// Oracle has no column-level auto-increment clause; surrogate keys
+	// are backed by a GENERATED ... AS IDENTITY column or a sequence,
+	// which is outside the scope of this basic CREATE TABLE emission.
+	return ""
+}
+
+func (oracleDialect) SupportsAlterConstraints() bool {
+	return true
+}
+
+func (oracleDialect) ComputedColumnClause(expr string) string {
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) VIRTUAL", expr)
+}
+
+func (oracleDialect) FunctionName(name string) string {
+	if name == "NOW" {
+		return "SYSDATE"
+	}
+	return name
+}
+
+func (d oracleDialect) RenameColumnClause(tableName, oldName string, field schema.TableField) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(oldName), d.QuoteIdentifier(field.Name))
+}
+
+// ModifyColumnClause uses Oracle's single MODIFY clause, which (unlike
+// Postgres) accepts the type, NOT NULL and DEFAULT together.
+func (d oracleDialect) ModifyColumnClause(tableName string, field schema.TableField) string {
+	return fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s);\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name), columnTypeAndModifiers(d, field))
+}
@@ -0,0 +1,100 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// postgresDialect targets PostgreSQL: double-quoted identifiers, BOOLEAN
+// natively and SERIAL for surrogate keys instead of a column clause.
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) QuoteIdentifier(name string) string {
+	return fmt.Sprintf("%q", name)
+}
+
+func (postgresDialect) ColumnType(field schema.TableField, isPrimaryKey bool) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer":
+		if isPrimaryKey {
+			return "SERIAL"
+		}
+		return "INTEGER"
+	case "bigint":
+		if isPrimaryKey {
+			return "BIGSERIAL"
+		}
+		return "BIGINT"
+	case "bool", "boolean":
+		return "BOOLEAN"
+	case "decimal":
+		return "DECIMAL" + renderLength(field)
+	case "varchar", "string":
+		return "VARCHAR" + renderLength(field)
+	case "text":
+		return "TEXT"
+	case "datetime":
+		return "TIMESTAMP"
+	case "date":
+		return "DATE"
+	default:
+		return strings.ToUpper(field.Type) + renderLength(field)
+	}
+}
+
+func (postgresDialect) RenderDefault(field schema.TableField) string {
+	switch field.DefaultType {
+	case "Function":
+		return field.DefaultValue
+	case "Expression":
+		return fmt.Sprintf("'%s'", field.DefaultValue)
+	default:
+		return field.DefaultValue
+	}
+}
+
+func (postgresDialect) AutoIncrementClause() string {
+	// Postgres expresses auto-increment via the SERIAL/BIGSERIAL type
+	// rather than a column clause; ColumnType handles that case, so
+	// there is nothing to append here.
+	return ""
+}
+
+func (postgresDialect) SupportsAlterConstraints() bool {
+	return true
+}
+
+func (postgresDialect) ComputedColumnClause(expr string) string {
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", expr)
+}
+
+func (postgresDialect) FunctionName(name string) string {
+	return name
+}
+
+func (d postgresDialect) RenameColumnClause(tableName, oldName string, field schema.TableField) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(oldName), d.QuoteIdentifier(field.Name))
+}
+
+// ModifyColumnClause renders Postgres's three separate ALTER COLUMN forms:
+// Postgres has no single clause covering type, nullability and default
+// together, so each is its own statement reflecting field's target state.
+func (d postgresDialect) ModifyColumnClause(tableName string, field schema.TableField) string {
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "ALTER TABLE %s ALTER COLUMN %s TYPE %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name), d.ColumnType(field, false))
+	if field.IsNullable {
+		fmt.Fprintf(&sql, "ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name))
+	} else {
+		fmt.Fprintf(&sql, "ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name))
+	}
+	if field.DefaultType == "None" {
+		fmt.Fprintf(&sql, "ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name))
+	} else {
+		fmt.Fprintf(&sql, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(field.Name), d.RenderDefault(field))
+	}
+	return sql.String()
+}
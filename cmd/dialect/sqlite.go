@@ -0,0 +1,87 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// sqliteDialect targets SQLite, which is dynamically typed: most declared
+// types collapse to a handful of storage classes and lengths are
+// informational only.
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) QuoteIdentifier(name string) string {
+	return fmt.Sprintf("%q", name)
+}
+
+func (sqliteDialect) ColumnType(field schema.TableField, isPrimaryKey bool) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer":
+		return "INTEGER"
+	case "bool", "boolean":
+		return "BOOLEAN"
+	case "decimal":
+		return "NUMERIC"
+	case "varchar", "string":
+		return "TEXT"
+	case "text":
+		return "TEXT"
+	case "datetime":
+		return "DATETIME"
+	case "date":
+		return "DATE"
+	default:
+		return strings.ToUpper(field.Type)
+	}
+}
+
+func (sqliteDialect) RenderDefault(field schema.TableField) string {
+	switch field.DefaultType {
+	case "Function":
+		return field.DefaultValue
+	case "Expression":
+		return fmt.Sprintf("'%s'", field.DefaultValue)
+	default:
+		return field.DefaultValue
+	}
+}
+
+// AutoIncrementClause returns "PRIMARY KEY AUTOINCREMENT": SQLite's
+// AUTOINCREMENT keyword is only legal directly after an inline PRIMARY
+// KEY column-constraint, so the two are always emitted together here
+// rather than as a separate ALTER TABLE ... ADD CONSTRAINT (which
+// SupportsAlterConstraints reports SQLite can't do anyway).
+func (sqliteDialect) AutoIncrementClause() string {
+	return "PRIMARY KEY AUTOINCREMENT"
+}
+
+func (sqliteDialect) SupportsAlterConstraints() bool {
+	return false
+}
+
+func (sqliteDialect) ComputedColumnClause(expr string) string {
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", expr)
+}
+
+func (sqliteDialect) FunctionName(name string) string {
+	return name
+}
+
+// RenameColumnClause uses SQLite's own ALTER TABLE ... RENAME COLUMN
+// (supported since 3.25.0), which unlike ADD CONSTRAINT is a genuine
+// ALTER TABLE capability here.
+func (d sqliteDialect) RenameColumnClause(tableName, oldName string, field schema.TableField) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier(oldName), d.QuoteIdentifier(field.Name))
+}
+
+// ModifyColumnClause returns "": SQLite has no ALTER COLUMN at all.
+// Changing a column's type requires rebuilding the table (create a new
+// table, copy the data across, drop the old one, rename), which this
+// tool doesn't attempt.
+func (sqliteDialect) ModifyColumnClause(tableName string, field schema.TableField) string {
+	return ""
+}
@@ -0,0 +1,260 @@
+// Package diff computes the structural difference between two NDM2
+// schemas and renders it as ALTER statements, the mirror image of the
+// CREATE-only generation path in cmd.
+package diff
+
+import (
+	"sort"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// ColumnRename pairs an old column name with its new name, either
+// supplied via --rename-map or inferred heuristically by Compute.
+// OldField/NewField carry the column's full definition on each side,
+// since some dialects (MySQL's CHANGE COLUMN) must redeclare a renamed
+// column's whole definition rather than just its name.
+type ColumnRename struct {
+	From string
+	To   string
+
+	OldField schema.TableField
+	NewField schema.TableField
+}
+
+// ColumnChange describes a column that exists in both schemas but whose
+// definition (type, length, nullability, default, ...) changed.
+type ColumnChange struct {
+	Old schema.TableField
+	New schema.TableField
+}
+
+// TableDiff describes the changes detected for a single table that
+// exists in both the old and new schema.
+type TableDiff struct {
+	Name string
+
+	AddedColumns    []schema.TableField
+	DroppedColumns  []schema.TableField
+	RenamedColumns  []ColumnRename
+	ModifiedColumns []ColumnChange
+
+	PrimaryKeyChanged bool
+	OldPrimaryKey     schema.PrimaryKey
+	NewPrimaryKey     schema.PrimaryKey
+
+	AddedForeignKeys   []schema.ForeignKey
+	DroppedForeignKeys []schema.ForeignKey
+}
+
+// SchemaDiff is the full structural difference between two NDM2 schemas.
+type SchemaDiff struct {
+	AddedTables    []schema.Table
+	DroppedTables  []schema.Table
+	ModifiedTables []TableDiff
+}
+
+// Compute diffs the tables of oldFile against newFile. renameMap maps an
+// old column name to its new name (scoped as "table.column") for columns
+// the caller already knows were renamed; any column rename not present in
+// renameMap is instead inferred heuristically by matching a dropped
+// column to an added column of the same type and length. Column position
+// is not considered: candidates are paired in column-name order.
+func Compute(oldFile, newFile schema.NDM2File, renameMap map[string]string) SchemaDiff {
+	oldTables := collectTables(oldFile)
+	newTables := collectTables(newFile)
+
+	var result SchemaDiff
+	for name, newTable := range newTables {
+		if _, ok := oldTables[name]; !ok {
+			result.AddedTables = append(result.AddedTables, newTable)
+		}
+	}
+	for name, oldTable := range oldTables {
+		if _, ok := newTables[name]; !ok {
+			result.DroppedTables = append(result.DroppedTables, oldTable)
+		}
+	}
+	for name, newTable := range newTables {
+		oldTable, ok := oldTables[name]
+		if !ok {
+			continue
+		}
+		if tableDiff := diffTable(oldTable, newTable, renameMap); hasChanges(tableDiff) {
+			result.ModifiedTables = append(result.ModifiedTables, tableDiff)
+		}
+	}
+	return result
+}
+
+// collectTables flattens every table across every catalog/schema into a
+// map keyed by table name.
+func collectTables(file schema.NDM2File) map[string]schema.Table {
+	tables := map[string]schema.Table{}
+	for _, catalog := range file.Server.Catalogs {
+		for _, sch := range catalog.Schemas {
+			for _, table := range sch.Tables {
+				tables[table.Name] = table
+			}
+		}
+	}
+	return tables
+}
+
+func diffTable(oldTable, newTable schema.Table, renameMap map[string]string) TableDiff {
+	result := TableDiff{Name: newTable.Name}
+
+	oldColumns := fieldsByName(oldTable)
+	newColumns := fieldsByName(newTable)
+
+	renamed := resolveRenames(oldTable.Name, oldColumns, newColumns, renameMap)
+	renamedTo := make(map[string]bool, len(renamed))
+	for _, to := range renamed {
+		renamedTo[to] = true
+	}
+
+	for name, newField := range newColumns {
+		if renamedTo[name] {
+			continue
+		}
+		oldField, existed := oldColumns[name]
+		if !existed {
+			result.AddedColumns = append(result.AddedColumns, newField)
+			continue
+		}
+		if oldField != newField {
+			result.ModifiedColumns = append(result.ModifiedColumns, ColumnChange{Old: oldField, New: newField})
+		}
+	}
+	for name, oldField := range oldColumns {
+		if _, renamedAway := renamed[name]; renamedAway {
+			continue
+		}
+		if _, stillExists := newColumns[name]; !stillExists {
+			result.DroppedColumns = append(result.DroppedColumns, oldField)
+		}
+	}
+	for from, to := range renamed {
+		result.RenamedColumns = append(result.RenamedColumns, ColumnRename{
+			From: from, To: to,
+			OldField: oldColumns[from], NewField: newColumns[to],
+		})
+	}
+
+	if !samePrimaryKey(oldTable.PrimaryKey, newTable.PrimaryKey) {
+		result.PrimaryKeyChanged = true
+		result.OldPrimaryKey = oldTable.PrimaryKey
+		result.NewPrimaryKey = newTable.PrimaryKey
+	}
+
+	result.AddedForeignKeys, result.DroppedForeignKeys = diffForeignKeys(oldTable.ForeignKeys, newTable.ForeignKeys)
+
+	return result
+}
+
+func fieldsByName(table schema.Table) map[string]schema.TableField {
+	fields := make(map[string]schema.TableField, len(table.Fields))
+	for _, field := range table.Fields {
+		fields[field.Name] = field
+	}
+	return fields
+}
+
+// resolveRenames determines which old columns became which new columns,
+// returning a map of old column name to new column name. It first honors
+// an explicit "table.column" entry in renameMap, then falls back to a
+// same-type-and-length heuristic between the columns left over once
+// exact-name matches are excluded (column position is not compared).
+func resolveRenames(tableName string, oldColumns, newColumns map[string]schema.TableField, renameMap map[string]string) map[string]string {
+	renamed := map[string]string{}
+	usedNewNames := map[string]bool{}
+
+	for oldName, newName := range renameMap {
+		const sep = "."
+		prefix := tableName + sep
+		if len(oldName) <= len(prefix) || oldName[:len(prefix)] != prefix {
+			continue
+		}
+		plainOldName := oldName[len(prefix):]
+		if _, ok := oldColumns[plainOldName]; !ok {
+			continue
+		}
+		if _, ok := newColumns[newName]; !ok {
+			continue
+		}
+		renamed[plainOldName] = newName
+		usedNewNames[newName] = true
+	}
+
+	var droppedOnly, addedOnly []schema.TableField
+	for name, field := range oldColumns {
+		if _, stillExists := newColumns[name]; !stillExists && renamed[name] == "" {
+			droppedOnly = append(droppedOnly, field)
+		}
+	}
+	for name, field := range newColumns {
+		if _, existed := oldColumns[name]; !existed && !usedNewNames[name] {
+			addedOnly = append(addedOnly, field)
+		}
+	}
+	// oldColumns/newColumns are maps, so droppedOnly/addedOnly come out in
+	// random order; sort by name before pairing so an ambiguous rename
+	// (several same-type/length candidates) resolves the same way on every
+	// run instead of picking a different, possibly crossed, pairing each
+	// time.
+	sort.Slice(droppedOnly, func(i, j int) bool { return droppedOnly[i].Name < droppedOnly[j].Name })
+	sort.Slice(addedOnly, func(i, j int) bool { return addedOnly[i].Name < addedOnly[j].Name })
+	for _, dropped := range droppedOnly {
+		for _, added := range addedOnly {
+			if usedNewNames[added.Name] || renamed[dropped.Name] != "" {
+				continue
+			}
+			if dropped.Type == added.Type && dropped.Length == added.Length {
+				renamed[dropped.Name] = added.Name
+				usedNewNames[added.Name] = true
+			}
+		}
+	}
+	return renamed
+}
+
+func samePrimaryKey(a, b schema.PrimaryKey) bool {
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i] != b.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func diffForeignKeys(oldKeys, newKeys []schema.ForeignKey) (added, dropped []schema.ForeignKey) {
+	oldByName := make(map[string]schema.ForeignKey, len(oldKeys))
+	for _, fk := range oldKeys {
+		oldByName[fk.Name] = fk
+	}
+	newByName := make(map[string]schema.ForeignKey, len(newKeys))
+	for _, fk := range newKeys {
+		newByName[fk.Name] = fk
+	}
+
+	for _, fk := range newKeys {
+		if _, ok := oldByName[fk.Name]; !ok {
+			added = append(added, fk)
+		}
+	}
+	for _, fk := range oldKeys {
+		if _, ok := newByName[fk.Name]; !ok {
+			dropped = append(dropped, fk)
+		}
+	}
+	return added, dropped
+}
+
+func hasChanges(t TableDiff) bool {
+	return len(t.AddedColumns) > 0 || len(t.DroppedColumns) > 0 || len(t.RenamedColumns) > 0 ||
+		len(t.ModifiedColumns) > 0 || t.PrimaryKeyChanged ||
+		len(t.AddedForeignKeys) > 0 || len(t.DroppedForeignKeys) > 0
+}
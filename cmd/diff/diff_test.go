@@ -0,0 +1,126 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+func tableWithFields(name string, fields ...schema.TableField) schema.Table {
+	return schema.Table{Name: name, Fields: fields}
+}
+
+func fileWithTables(tables ...schema.Table) schema.NDM2File {
+	return schema.NDM2File{Server: schema.Server{Catalogs: []schema.Catalog{
+		{Schemas: []schema.Schema{{Tables: tables}}},
+	}}}
+}
+
+func TestDiffComputeExplicitRename(t *testing.T) {
+	oldFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "full_name", Type: "varchar", Length: 255},
+	))
+	newFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "name", Type: "varchar", Length: 255},
+	))
+
+	result := Compute(oldFile, newFile, map[string]string{"users.full_name": "name"})
+
+	if len(result.ModifiedTables) != 1 {
+		t.Fatalf("expected 1 modified table, got %d", len(result.ModifiedTables))
+	}
+	table := result.ModifiedTables[0]
+
+	if len(table.RenamedColumns) != 1 {
+		t.Fatalf("expected 1 renamed column, got %d: %+v", len(table.RenamedColumns), table.RenamedColumns)
+	}
+	rename := table.RenamedColumns[0]
+	if rename.From != "full_name" || rename.To != "name" {
+		t.Fatalf("expected rename full_name -> name, got %s -> %s", rename.From, rename.To)
+	}
+	if len(table.AddedColumns) != 0 || len(table.DroppedColumns) != 0 {
+		t.Fatalf("expected no added/dropped columns for a renamed pair, got added=%v dropped=%v", table.AddedColumns, table.DroppedColumns)
+	}
+}
+
+func TestDiffComputeHeuristicRename(t *testing.T) {
+	oldFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "nickname", Type: "varchar", Length: 64},
+	))
+	newFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "display_name", Type: "varchar", Length: 64},
+	))
+
+	result := Compute(oldFile, newFile, nil)
+
+	if len(result.ModifiedTables) != 1 {
+		t.Fatalf("expected 1 modified table, got %d", len(result.ModifiedTables))
+	}
+	table := result.ModifiedTables[0]
+
+	if len(table.RenamedColumns) != 1 {
+		t.Fatalf("expected 1 renamed column, got %d: %+v", len(table.RenamedColumns), table.RenamedColumns)
+	}
+	rename := table.RenamedColumns[0]
+	if rename.From != "nickname" || rename.To != "display_name" {
+		t.Fatalf("expected rename nickname -> display_name, got %s -> %s", rename.From, rename.To)
+	}
+}
+
+func TestDiffComputeHeuristicRenameIsDeterministicAcrossAmbiguousCandidates(t *testing.T) {
+	oldFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "a_old", Type: "varchar", Length: 32},
+		schema.TableField{Name: "b_old", Type: "varchar", Length: 32},
+	))
+	newFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "a_new", Type: "varchar", Length: 32},
+		schema.TableField{Name: "b_new", Type: "varchar", Length: 32},
+	))
+
+	var first map[string]string
+	for i := 0; i < 50; i++ {
+		result := Compute(oldFile, newFile, nil)
+		table := result.ModifiedTables[0]
+		got := map[string]string{}
+		for _, rename := range table.RenamedColumns {
+			got[rename.From] = rename.To
+		}
+		if first == nil {
+			first = got
+			continue
+		}
+		if got["a_old"] != first["a_old"] || got["b_old"] != first["b_old"] {
+			t.Fatalf("rename pairing changed across runs: first=%v, got=%v", first, got)
+		}
+	}
+}
+
+func TestDiffComputeNoSpuriousRenameAcrossIncompatibleTypes(t *testing.T) {
+	oldFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "age", Type: "int"},
+	))
+	newFile := fileWithTables(tableWithFields("users",
+		schema.TableField{Name: "id", Type: "int"},
+		schema.TableField{Name: "bio", Type: "text"},
+	))
+
+	result := Compute(oldFile, newFile, nil)
+	table := result.ModifiedTables[0]
+
+	if len(table.RenamedColumns) != 0 {
+		t.Fatalf("expected no renames between incompatible types, got %+v", table.RenamedColumns)
+	}
+	if len(table.DroppedColumns) != 1 || table.DroppedColumns[0].Name != "age" {
+		t.Fatalf("expected age dropped, got %+v", table.DroppedColumns)
+	}
+	if len(table.AddedColumns) != 1 || table.AddedColumns[0].Name != "bio" {
+		t.Fatalf("expected bio added, got %+v", table.AddedColumns)
+	}
+}
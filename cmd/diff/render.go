@@ -0,0 +1,178 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/dialect"
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// Render turns a SchemaDiff into an "up" migration script and, when down
+// is true, a matching "down" rollback script, both rendered for the given
+// dialect. Statements are ordered so dependencies are respected: foreign
+// keys are dropped before the columns/tables they reference change, and
+// re-added only once the new shape is in place.
+func Render(diff SchemaDiff, d dialect.Dialect, down bool) (up string, downSQL string) {
+	var upSQL strings.Builder
+
+	for _, table := range diff.ModifiedTables {
+		for _, fk := range table.DroppedForeignKeys {
+			fmt.Fprintf(&upSQL, "ALTER TABLE %s DROP CONSTRAINT %s;\n\n", d.QuoteIdentifier(table.Name), d.QuoteIdentifier(fk.Name))
+		}
+	}
+
+	for _, table := range diff.DroppedTables {
+		fmt.Fprintf(&upSQL, "DROP TABLE %s;\n\n", d.QuoteIdentifier(table.Name))
+	}
+
+	for _, table := range diff.AddedTables {
+		upSQL.WriteString(renderCreateTable(table, d))
+	}
+
+	for _, table := range diff.ModifiedTables {
+		for _, field := range table.DroppedColumns {
+			fmt.Fprintf(&upSQL, "ALTER TABLE %s DROP COLUMN %s;\n\n", d.QuoteIdentifier(table.Name), d.QuoteIdentifier(field.Name))
+		}
+		for _, rename := range table.RenamedColumns {
+			upSQL.WriteString(d.RenameColumnClause(table.Name, rename.From, rename.NewField))
+		}
+		for _, change := range table.ModifiedColumns {
+			upSQL.WriteString(d.ModifyColumnClause(table.Name, change.New))
+		}
+		for _, field := range table.AddedColumns {
+			fmt.Fprintf(&upSQL, "ALTER TABLE %s ADD COLUMN %s;\n\n", d.QuoteIdentifier(table.Name), renderAddedColumnDefinition(field, d))
+		}
+		if table.PrimaryKeyChanged {
+			upSQL.WriteString(renderPrimaryKeyChange(table.Name, table.OldPrimaryKey, table.NewPrimaryKey, d))
+		}
+	}
+
+	for _, table := range diff.ModifiedTables {
+		for _, fk := range table.AddedForeignKeys {
+			upSQL.WriteString(renderAddForeignKey(table.Name, fk, d))
+			fmt.Fprintf(&upSQL, "CREATE INDEX %s ON %s (%s);\n\n",
+				d.QuoteIdentifier(fmt.Sprintf("idx_fk_%s_%s", table.Name, fk.ReferenceTable)),
+				d.QuoteIdentifier(table.Name),
+				strings.Join(quoteAll(d, fk.Fields), ", "))
+		}
+	}
+
+	if !down {
+		return upSQL.String(), ""
+	}
+	return upSQL.String(), renderDown(diff, d)
+}
+
+// renderDown produces the rollback script: every change in diff applied
+// in reverse, in reverse order.
+func renderDown(diff SchemaDiff, d dialect.Dialect) string {
+	var downSQL strings.Builder
+
+	for _, table := range diff.ModifiedTables {
+		for _, fk := range table.AddedForeignKeys {
+			fmt.Fprintf(&downSQL, "ALTER TABLE %s DROP CONSTRAINT %s;\n\n", d.QuoteIdentifier(table.Name), d.QuoteIdentifier(fk.Name))
+		}
+		if table.PrimaryKeyChanged {
+			downSQL.WriteString(renderPrimaryKeyChange(table.Name, table.NewPrimaryKey, table.OldPrimaryKey, d))
+		}
+		for _, field := range table.AddedColumns {
+			fmt.Fprintf(&downSQL, "ALTER TABLE %s DROP COLUMN %s;\n\n", d.QuoteIdentifier(table.Name), d.QuoteIdentifier(field.Name))
+		}
+		for _, change := range table.ModifiedColumns {
+			downSQL.WriteString(d.ModifyColumnClause(table.Name, change.Old))
+		}
+		for _, rename := range table.RenamedColumns {
+			downSQL.WriteString(d.RenameColumnClause(table.Name, rename.To, rename.OldField))
+		}
+		for _, field := range table.DroppedColumns {
+			fmt.Fprintf(&downSQL, "ALTER TABLE %s ADD COLUMN %s;\n\n", d.QuoteIdentifier(table.Name), renderAddedColumnDefinition(field, d))
+		}
+	}
+
+	for _, table := range diff.AddedTables {
+		fmt.Fprintf(&downSQL, "DROP TABLE %s;\n\n", d.QuoteIdentifier(table.Name))
+	}
+
+	for _, table := range diff.DroppedTables {
+		downSQL.WriteString(renderCreateTable(table, d))
+	}
+
+	for _, table := range diff.ModifiedTables {
+		for _, fk := range table.DroppedForeignKeys {
+			downSQL.WriteString(renderAddForeignKey(table.Name, fk, d))
+		}
+	}
+
+	return downSQL.String()
+}
+
+func renderCreateTable(table schema.Table, d dialect.Dialect) string {
+	var fieldsSQL []string
+	for _, field := range table.Fields {
+		fieldsSQL = append(fieldsSQL, renderColumnDefinition(table, field, d))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);\n\n", d.QuoteIdentifier(table.Name), strings.Join(fieldsSQL, ",\n  "))
+}
+
+func renderColumnDefinition(table schema.Table, field schema.TableField, d dialect.Dialect) string {
+	fieldSQL := fmt.Sprintf("%s %s", d.QuoteIdentifier(field.Name), d.ColumnType(field, isSinglePrimaryKeyField(table, field)))
+	if !field.IsNullable {
+		fieldSQL += " NOT NULL"
+	}
+	if field.DefaultType != "None" {
+		fieldSQL += fmt.Sprintf(" DEFAULT %s", d.RenderDefault(field))
+	}
+	return fieldSQL
+}
+
+// renderAddedColumnDefinition renders the column definition for an ADD
+// COLUMN statement. ColumnType is always called with isPrimaryKey false
+// here, so no auto-increment-type substitution (e.g. Postgres's SERIAL) is
+// applied, even when the added column is part of the new primary key;
+// primary key changes are rendered separately by renderPrimaryKeyChange.
+func renderAddedColumnDefinition(field schema.TableField, d dialect.Dialect) string {
+	fieldSQL := fmt.Sprintf("%s %s", d.QuoteIdentifier(field.Name), d.ColumnType(field, false))
+	if !field.IsNullable {
+		fieldSQL += " NOT NULL"
+	}
+	if field.DefaultType != "None" {
+		fieldSQL += fmt.Sprintf(" DEFAULT %s", d.RenderDefault(field))
+	}
+	return fieldSQL
+}
+
+// isSinglePrimaryKeyField reports whether field is table's sole
+// primary-key field, the case in which a dialect may substitute an
+// auto-increment type (e.g. Postgres's SERIAL) in ColumnType.
+func isSinglePrimaryKeyField(table schema.Table, field schema.TableField) bool {
+	return len(table.PrimaryKey.Fields) == 1 && table.PrimaryKey.Fields[0] == field.Name
+}
+
+func renderPrimaryKeyChange(tableName string, oldPK, newPK schema.PrimaryKey, d dialect.Dialect) string {
+	var sql strings.Builder
+	if len(oldPK.Fields) > 0 {
+		fmt.Fprintf(&sql, "ALTER TABLE %s DROP CONSTRAINT %s;\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier("pk_"+tableName))
+	}
+	if len(newPK.Fields) > 0 {
+		fmt.Fprintf(&sql, "ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);\n\n", d.QuoteIdentifier(tableName), d.QuoteIdentifier("pk_"+tableName), strings.Join(quoteAll(d, newPK.Fields), ", "))
+	}
+	return sql.String()
+}
+
+func renderAddForeignKey(tableName string, fk schema.ForeignKey, d dialect.Dialect) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s);\n\n",
+		d.QuoteIdentifier(tableName),
+		d.QuoteIdentifier(fk.Name),
+		strings.Join(quoteAll(d, fk.Fields), ", "),
+		d.QuoteIdentifier(fk.ReferenceTable),
+		strings.Join(quoteAll(d, fk.ReferenceFields), ", "))
+}
+
+func quoteAll(d dialect.Dialect, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.QuoteIdentifier(name)
+	}
+	return quoted
+}
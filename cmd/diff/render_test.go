@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/willianricardo/ndm2sql/cmd/dialect"
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+func field(name, typ string, length int) schema.TableField {
+	return schema.TableField{Name: name, Type: typ, Length: length, DefaultType: "None"}
+}
+
+func TestRenderRenameColumnPerDialect(t *testing.T) {
+	diff := SchemaDiff{ModifiedTables: []TableDiff{{
+		Name: "users",
+		RenamedColumns: []ColumnRename{{
+			From:     "nickname",
+			To:       "display_name",
+			OldField: field("nickname", "varchar", 64),
+			NewField: field("display_name", "varchar", 64),
+		}},
+	}}}
+
+	cases := map[string]string{
+		"mysql":    "CHANGE COLUMN",
+		"postgres": "RENAME COLUMN",
+		"mssql":    "sp_rename",
+		"sqlite":   "RENAME COLUMN",
+		"oracle":   "RENAME COLUMN",
+	}
+	for name, want := range cases {
+		d, err := dialect.Resolve(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		up, _ := Render(diff, d, false)
+		if !strings.Contains(up, want) {
+			t.Errorf("%s: expected output to contain %q, got %q", name, want, up)
+		}
+	}
+}
+
+func TestRenderModifyColumnSQLiteUnsupported(t *testing.T) {
+	diff := SchemaDiff{ModifiedTables: []TableDiff{{
+		Name:            "users",
+		ModifiedColumns: []ColumnChange{{Old: field("nickname", "varchar", 64), New: field("nickname", "varchar", 128)}},
+	}}}
+
+	d, err := dialect.Resolve("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	up, _ := Render(diff, d, false)
+	if strings.Contains(up, "ALTER") {
+		t.Fatalf("expected no ALTER statement for an unsupported SQLite column type change, got %q", up)
+	}
+}
@@ -0,0 +1,62 @@
+// Package expr implements a small expression language for NDM2 computed
+// columns and CHECK constraints: a tokenizer, a recursive-descent parser
+// and a per-dialect emitter. The grammar supports field references,
+// numeric/string literals, the arithmetic/comparison/logical operators
+// and a whitelisted set of functions.
+package expr
+
+// Node is any node in the expression AST.
+type Node interface {
+	node()
+}
+
+// ValueExprAST is a numeric or string literal.
+type ValueExprAST struct {
+	// Raw is the literal exactly as written: an unquoted number such as
+	// "42" or "3.5", or a quoted string such as "'hello'".
+	Raw string
+}
+
+// FieldExprAST is a reference to a column on the table the expression is
+// attached to.
+type FieldExprAST struct {
+	Name string
+}
+
+// BinaryExprAST is a binary operator application: arithmetic (+ - * / %),
+// comparison (= != < > <= >=) or logical (AND, OR).
+type BinaryExprAST struct {
+	Op    string
+	Left  Node
+	Right Node
+}
+
+// UnaryExprAST is a unary operator application: NOT or unary minus (-).
+type UnaryExprAST struct {
+	Op      string
+	Operand Node
+}
+
+// FunCallerExprAST is a call to a whitelisted function.
+type FunCallerExprAST struct {
+	Name string
+	Args []Node
+}
+
+func (ValueExprAST) node()     {}
+func (FieldExprAST) node()     {}
+func (BinaryExprAST) node()    {}
+func (UnaryExprAST) node()     {}
+func (FunCallerExprAST) node() {}
+
+// Functions is the whitelist of function names the parser accepts;
+// anything else is a parse error.
+var Functions = map[string]bool{
+	"SUM":               true,
+	"COALESCE":          true,
+	"LOWER":             true,
+	"UPPER":             true,
+	"LENGTH":            true,
+	"NOW":               true,
+	"CURRENT_TIMESTAMP": true,
+}
@@ -0,0 +1,70 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/dialect"
+)
+
+// Emit renders node as SQL for the given dialect, quoting field
+// references and rewriting whitelisted function names via
+// dialect.FunctionName.
+func Emit(node Node, d dialect.Dialect) (string, error) {
+	switch n := node.(type) {
+	case ValueExprAST:
+		return n.Raw, nil
+
+	case FieldExprAST:
+		return d.QuoteIdentifier(n.Name), nil
+
+	case UnaryExprAST:
+		operand, err := Emit(n.Operand, d)
+		if err != nil {
+			return "", err
+		}
+		if n.Op == "NOT" {
+			return fmt.Sprintf("NOT (%s)", operand), nil
+		}
+		return n.Op + operand, nil
+
+	case BinaryExprAST:
+		left, err := Emit(n.Left, d)
+		if err != nil {
+			return "", err
+		}
+		right, err := Emit(n.Right, d)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s %s %s)", left, n.Op, right), nil
+
+	case FunCallerExprAST:
+		name := d.FunctionName(n.Name)
+		if len(n.Args) == 0 && (n.Name == "NOW" || n.Name == "CURRENT_TIMESTAMP") {
+			return name, nil
+		}
+		args := make([]string, len(n.Args))
+		for i, arg := range n.Args {
+			rendered, err := Emit(arg, d)
+			if err != nil {
+				return "", err
+			}
+			args[i] = rendered
+		}
+		return fmt.Sprintf("%s(%s)", name, strings.Join(args, ", ")), nil
+
+	default:
+		return "", fmt.Errorf("expr: unhandled node type %T", node)
+	}
+}
+
+// EmitString parses and renders expression source in one step, the
+// common case for a Computed field or CheckConstraint.Expression.
+func EmitString(source string, d dialect.Dialect) (string, error) {
+	node, err := Parse(source)
+	if err != nil {
+		return "", err
+	}
+	return Emit(node, d)
+}
@@ -0,0 +1,112 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lex splits input into tokens, recognizing identifiers/keywords, numeric
+// literals, single-quoted string literals, parens, commas and the
+// operators this grammar supports.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+		case r == '\'':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != '\'' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string literal starting at position %d", start)
+			}
+			i++ // consume closing quote
+			tokens = append(tokens, token{tokenString, string(runes[start:i])})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[start:i])})
+		default:
+			op, width, err := lexOperator(runes[i:])
+			if err != nil {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+			tokens = append(tokens, token{tokenOp, op})
+			i += width
+		}
+	}
+	tokens = append(tokens, token{tokenEOF, ""})
+	return tokens, nil
+}
+
+// lexOperator matches the longest operator starting at runes, returning
+// its canonical spelling and how many runes it consumed.
+func lexOperator(runes []rune) (string, int, error) {
+	two := ""
+	if len(runes) >= 2 {
+		two = string(runes[:2])
+	}
+	switch two {
+	case "!=", "<>":
+		return "!=", 2, nil
+	case "<=", ">=":
+		return two, 2, nil
+	}
+	switch runes[0] {
+	case '+', '-', '*', '/', '%', '=', '<', '>':
+		return string(runes[0]), 1, nil
+	}
+	return "", 0, fmt.Errorf("unknown operator starting with %q", runes[0])
+}
+
+// isKeyword reports whether ident (already uppercased) is a reserved
+// grammar keyword rather than a field name.
+func isKeyword(ident string) bool {
+	switch strings.ToUpper(ident) {
+	case "AND", "OR", "NOT":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse parses input into an expression AST, used for both TableField.Computed
+// and CheckConstraint.Expression.
+func Parse(input string) (Node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected trailing input near %q", p.peek().text)
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && strings.EqualFold(tok.text, word)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExprAST{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExprAST{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Node, error) {
+	if p.isKeyword("NOT") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExprAST{Op: "NOT", Operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind == tokenOp && isComparisonOp(tok.text) {
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return BinaryExprAST{Op: tok.text, Left: left, Right: right}, nil
+	}
+	return left, nil
+}
+
+func isComparisonOp(op string) bool {
+	switch op {
+	case "=", "!=", "<", ">", "<=", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseAdditive() (Node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOp || (tok.text != "+" && tok.text != "-") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExprAST{Op: tok.text, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseMultiplicative() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOp || (tok.text != "*" && tok.text != "/" && tok.text != "%") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExprAST{Op: tok.text, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if tok := p.peek(); tok.kind == tokenOp && tok.text == "-" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExprAST{Op: "-", Operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return ValueExprAST{Raw: tok.text}, nil
+	case tokenString:
+		return ValueExprAST{Raw: tok.text}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, fmt.Errorf("expected closing parenthesis near %q", p.peek().text)
+		}
+		p.next()
+		return inner, nil
+	case tokenIdent:
+		name := strings.ToUpper(tok.text)
+		if isKeyword(name) {
+			return nil, fmt.Errorf("unexpected keyword %q", tok.text)
+		}
+		if p.peek().kind == tokenLParen {
+			return p.parseFunctionCall(name)
+		}
+		return FieldExprAST{Name: tok.text}, nil
+	default:
+		return nil, fmt.Errorf("unexpected token %q", tok.text)
+	}
+}
+
+func (p *parser) parseFunctionCall(name string) (Node, error) {
+	if !Functions[name] {
+		return nil, fmt.Errorf("function %q is not in the expression whitelist", name)
+	}
+	p.next() // consume '('
+	var args []Node
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if p.peek().kind != tokenRParen {
+		return nil, fmt.Errorf("expected closing parenthesis in call to %s", name)
+	}
+	p.next()
+	return FunCallerExprAST{Name: name, Args: args}, nil
+}
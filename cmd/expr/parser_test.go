@@ -0,0 +1,81 @@
+package expr
+
+import "testing"
+
+func TestParseFieldReference(t *testing.T) {
+	node, err := Parse("price")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field, ok := node.(FieldExprAST)
+	if !ok || field.Name != "price" {
+		t.Fatalf("expected FieldExprAST{Name: \"price\"}, got %#v", node)
+	}
+}
+
+func TestParseArithmeticPrecedence(t *testing.T) {
+	// price + tax * rate should bind as price + (tax * rate), not
+	// (price + tax) * rate.
+	node, err := Parse("price + tax * rate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	add, ok := node.(BinaryExprAST)
+	if !ok || add.Op != "+" {
+		t.Fatalf("expected top-level +, got %#v", node)
+	}
+	mul, ok := add.Right.(BinaryExprAST)
+	if !ok || mul.Op != "*" {
+		t.Fatalf("expected right-hand side to be a * expression, got %#v", add.Right)
+	}
+}
+
+func TestParseComparisonAndLogical(t *testing.T) {
+	node, err := Parse("age >= 18 AND NOT deleted")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	and, ok := node.(BinaryExprAST)
+	if !ok || and.Op != "AND" {
+		t.Fatalf("expected top-level AND, got %#v", node)
+	}
+	cmp, ok := and.Left.(BinaryExprAST)
+	if !ok || cmp.Op != ">=" {
+		t.Fatalf("expected left-hand side to be a >= comparison, got %#v", and.Left)
+	}
+	if _, ok := and.Right.(UnaryExprAST); !ok {
+		t.Fatalf("expected right-hand side to be a NOT expression, got %#v", and.Right)
+	}
+}
+
+func TestParseWhitelistedFunctionCall(t *testing.T) {
+	node, err := Parse("COALESCE(discount, 0)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	call, ok := node.(FunCallerExprAST)
+	if !ok || call.Name != "COALESCE" {
+		t.Fatalf("expected FunCallerExprAST{Name: \"COALESCE\"}, got %#v", node)
+	}
+	if len(call.Args) != 2 {
+		t.Fatalf("expected 2 arguments, got %d: %+v", len(call.Args), call.Args)
+	}
+}
+
+func TestParseRejectsNonWhitelistedFunction(t *testing.T) {
+	if _, err := Parse("EVAL(1)"); err == nil {
+		t.Fatal("expected an error for a non-whitelisted function call")
+	}
+}
+
+func TestParseRejectsUnterminatedString(t *testing.T) {
+	if _, err := Parse("name = 'unterminated"); err == nil {
+		t.Fatal("expected an error for an unterminated string literal")
+	}
+}
+
+func TestParseRejectsTrailingInput(t *testing.T) {
+	if _, err := Parse("1 + 1 )"); err == nil {
+		t.Fatal("expected an error for unbalanced trailing input")
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// generateTableFragment renders the full set of statements for a single
+// table (CREATE TABLE, primary key, foreign keys, and their indexes) as
+// one fragment, the unit the table cache stores and retrieves.
+func generateTableFragment(table Table, dialect Dialect) (string, error) {
+	createTableSQL, err := generateCreateTableSQL(table, dialect)
+	if err != nil {
+		return "", err
+	}
+	return createTableSQL +
+		generateCreatePrimaryKeySQL(table, dialect) +
+		generateCreateForeignKeySQL(table, dialect) +
+		generateCreateIndexSQL(table, dialect) +
+		generateCreateIndexForForeignKeySQL(table, dialect), nil
+}
+
+// generateSQLFromNDM2FileCached is the cache-aware counterpart to
+// generateSQLFromNDM2File: it renders one fragment per table (see
+// generateTableFragment) instead of five passes grouped by statement
+// kind, so each table's fragment can be looked up and stored in cache as
+// a single unit. Because a table's fragment includes its foreign keys,
+// tables are first reordered with schema.SortTablesByDependency so a
+// referenced table's CREATE TABLE lands before any fragment pointing at
+// it, regardless of the input file's table order; tables in a foreign key
+// cycle, and references across schemas, are not reordered. It reports
+// how many tables were served from cache versus regenerated.
+func generateSQLFromNDM2FileCached(file NDM2File, dialect Dialect, cache *tableCache) (sql string, cached int, regenerated int, err error) {
+	var out string
+	for _, catalog := range file.Server.Catalogs {
+		for _, sch := range catalog.Schemas {
+			for _, table := range schema.SortTablesByDependency(sch.Tables) {
+				key, err := tableCacheKey(table, dialect.Name())
+				if err != nil {
+					return "", cached, regenerated, fmt.Errorf("hashing table %s: %w", table.Name, err)
+				}
+
+				if fragment, ok := cache.get(key); ok {
+					out += fragment
+					cached++
+					continue
+				}
+
+				fragment, err := generateTableFragment(table, dialect)
+				if err != nil {
+					return "", cached, regenerated, err
+				}
+				if err := cache.put(key, fragment); err != nil {
+					return "", cached, regenerated, fmt.Errorf("caching table %s: %w", table.Name, err)
+				}
+				out += fragment
+				regenerated++
+			}
+		}
+	}
+	return out, cached, regenerated, nil
+}
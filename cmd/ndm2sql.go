@@ -2,133 +2,386 @@ package cmd
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/willianricardo/ndm2sql/cmd/dialect"
+	"github.com/willianricardo/ndm2sql/cmd/diff"
+	"github.com/willianricardo/ndm2sql/cmd/expr"
+	"github.com/willianricardo/ndm2sql/cmd/reverse"
+	"github.com/willianricardo/ndm2sql/cmd/schema"
 )
 
-type PrimaryKey struct {
-	Name   string   `json:"name"`
-	Fields []string `json:"fields"`
-}
+// These aliases let the rest of the package keep referring to NDM2File,
+// Table, TableField, Dialect, etc. directly, while cmd/schema and
+// cmd/dialect remain the single source of truth shared with cmd/reverse
+// and cmd/diff.
+type (
+	PrimaryKey = schema.PrimaryKey
+	ForeignKey = schema.ForeignKey
+	TableField = schema.TableField
+	Table      = schema.Table
+	Schema     = schema.Schema
+	Catalog    = schema.Catalog
+	Server     = schema.Server
+	NDM2File   = schema.NDM2File
+	Dialect    = dialect.Dialect
+)
 
-type ForeignKey struct {
-	Name            string   `json:"name"`
-	Fields          []string `json:"fields"`
-	ReferenceTable  string   `json:"referenceTable"`
-	ReferenceFields []string `json:"referenceFields"`
-}
+func Execute() error {
+	if len(os.Args) > 1 && os.Args[1] == "reverse" {
+		return executeReverse(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		return executeDiff(os.Args[2:])
+	}
 
-type TableField struct {
-	Name         string `json:"name"`
-	Type         string `json:"type"`
-	Length       int    `json:"length"`
-	Decimals     int    `json:"decimals"`
-	IsNullable   bool   `json:"isNullable"`
-	DefaultType  string `json:"defaultType"`
-	DefaultValue string `json:"defaultValue"`
-}
+	flagSet := flag.NewFlagSet("ndm2sql", flag.ContinueOnError)
+	dialectName := flagSet.String("dialect", dialect.DefaultName, "target SQL dialect: mysql, postgres, mssql, sqlite, oracle")
+	streamFlag := flagSet.String("stream", "auto", "stream large NDM2 files instead of loading them fully into memory: auto, true or false")
+	noCache := flagSet.Bool("no-cache", false, "always regenerate SQL instead of reusing cached per-table fragments")
+	cacheDirFlag := flagSet.String("cache-dir", "", "directory for cached per-table SQL fragments (default ~/.cache/ndm2sql)")
+	if err := flagSet.Parse(os.Args[1:]); err != nil {
+		return err
+	}
 
-type Table struct {
-	Name        string       `json:"name"`
-	Fields      []TableField `json:"fields"`
-	PrimaryKey  PrimaryKey   `json:"primaryKey"`
-	ForeignKeys []ForeignKey `json:"foreignKeys"`
-}
+	args := flagSet.Args()
+	if len(args) != 2 {
+		return fmt.Errorf("Usage: ndm2sql [--dialect <mysql|postgres|mssql|sqlite|oracle>] [--stream <auto|true|false>] [--no-cache] [--cache-dir <dir>] <inputFilePath> <outputFilePath>\n       ndm2sql reverse --dsn <dsn> --driver <mysql|postgres> --out <file>\n       ndm2sql diff <old.ndm2> <new.ndm2> --out <migration.sql>")
+	}
 
-type Schema struct {
-	Name   string  `json:"name"`
-	Tables []Table `json:"tables"`
-}
+	selectedDialect, err := dialect.Resolve(*dialectName)
+	if err != nil {
+		return err
+	}
+
+	inputFilePath := args[0]
+	outputFilePath := args[1]
+
+	useStream, err := resolveStreamMode(*streamFlag, inputFilePath)
+	if err != nil {
+		return err
+	}
 
-type Catalog struct {
-	Name    string   `json:"name"`
-	Schemas []Schema `json:"schemas"`
+	// The table cache only applies to the in-memory path: --stream
+	// exists precisely to avoid holding the parsed document in memory,
+	// and a single huge file is unlikely to be regenerated repeatedly
+	// the way a schema under active development is.
+	if useStream {
+		if err := generateSQLToFileStreamed(inputFilePath, outputFilePath, selectedDialect); err != nil {
+			return err
+		}
+		fmt.Printf("SQL saved to %s successfully.\n", outputFilePath)
+		return nil
+	}
+
+	parsedData, err := parseFile(inputFilePath)
+	if err != nil {
+		return err
+	}
+
+	if *noCache {
+		sql, err := generateSQLFromNDM2File(parsedData, selectedDialect)
+		if err != nil {
+			return err
+		}
+		if err := saveToFile(sql, outputFilePath); err != nil {
+			return err
+		}
+		fmt.Printf("SQL saved to %s successfully.\n", outputFilePath)
+		return nil
+	}
+
+	cacheDir := *cacheDirFlag
+	if cacheDir == "" {
+		cacheDir, err = defaultCacheDir()
+		if err != nil {
+			return err
+		}
+	}
+	cache, err := newTableCache(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	sql, cached, regenerated, err := generateSQLFromNDM2FileCached(parsedData, selectedDialect, cache)
+	if err != nil {
+		return err
+	}
+	if err := saveToFile(sql, outputFilePath); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d tables cached, %d regenerated\n", cached, regenerated)
+	fmt.Printf("SQL saved to %s successfully.\n", outputFilePath)
+	return nil
 }
 
-type Server struct {
-	Catalogs []Catalog `json:"catalogs"`
+// executeReverse handles "ndm2sql reverse", introspecting a live database
+// and writing the resulting schema out as an NDM2 file.
+func executeReverse(args []string) error {
+	flagSet := flag.NewFlagSet("ndm2sql reverse", flag.ContinueOnError)
+	dsn := flagSet.String("dsn", "", "data source name used to connect to the database")
+	driver := flagSet.String("driver", "", "source database driver: mysql or postgres")
+	out := flagSet.String("out", "", "path to write the generated NDM2 file to")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *dsn == "" || *driver == "" || *out == "" {
+		return fmt.Errorf("Usage: ndm2sql reverse --dsn <dsn> --driver <mysql|postgres> --out <file>")
+	}
+
+	return reverse.Run(reverse.Config{Driver: *driver, DSN: *dsn, Out: *out})
 }
 
-type NDM2File struct {
-	Server Server `json:"server"`
+// reorderFlagsFirst rearranges args so every "-name"/"--name" flag (and,
+// for names in valuedFlags, the token after it) comes before the
+// positional arguments, preserving each group's relative order. Go's flag
+// package stops parsing at the first non-flag token, so this lets a
+// command's flags and positional arguments appear in any order on the
+// command line.
+func reorderFlagsFirst(args []string, valuedFlags map[string]bool) []string {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		name := strings.TrimLeft(arg, "-")
+		if !strings.Contains(name, "=") && valuedFlags[name] && i+1 < len(args) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+	return append(flags, positional...)
 }
 
-func Execute() error {
-	if len(os.Args) != 3 {
-		return fmt.Errorf("Usage: ndm2sql <inputFilePath> <outputFilePath>")
+// executeDiff handles "ndm2sql diff", comparing two NDM2 files and
+// writing the resulting ALTER-statement migration (and, with --down, its
+// rollback script) out to disk.
+func executeDiff(args []string) error {
+	flagSet := flag.NewFlagSet("ndm2sql diff", flag.ContinueOnError)
+	dialectName := flagSet.String("dialect", dialect.DefaultName, "target SQL dialect: mysql, postgres, mssql, sqlite, oracle")
+	out := flagSet.String("out", "", "path to write the generated migration SQL to")
+	down := flagSet.String("down", "", "path to write the generated rollback SQL to (omit to skip)")
+	renameMapFlag := flagSet.String("rename-map", "", "comma-separated table.oldColumn=newColumn pairs for known renames")
+
+	// The documented usage interspersed flags with the <old.ndm2>
+	// <new.ndm2> positional arguments, but Go's flag package stops
+	// parsing at the first non-flag token; reorder so every flag (and its
+	// value) is seen before the positional arguments regardless of where
+	// the caller put them.
+	valuedFlags := map[string]bool{"dialect": true, "out": true, "down": true, "rename-map": true}
+	if err := flagSet.Parse(reorderFlagsFirst(args, valuedFlags)); err != nil {
+		return err
 	}
 
-	inputFilePath := os.Args[1]
-	outputFilePath := os.Args[2]
+	args = flagSet.Args()
+	if len(args) != 2 || *out == "" {
+		return fmt.Errorf("Usage: ndm2sql diff <old.ndm2> <new.ndm2> --out <migration.sql> [--down <rollback.sql>] [--rename-map table.old=new,...]")
+	}
 
-	parsedData, err := parseFile(inputFilePath)
+	selectedDialect, err := dialect.Resolve(*dialectName)
+	if err != nil {
+		return err
+	}
+
+	oldFile, err := parseFile(args[0])
+	if err != nil {
+		return err
+	}
+	newFile, err := parseFile(args[1])
 	if err != nil {
 		return err
 	}
 
-	sql := generateSQLFromNDM2File(parsedData)
-	err = saveToFile(sql, outputFilePath)
+	renameMap, err := parseRenameMap(*renameMapFlag)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("SQL saved to %s successfully.\n", outputFilePath)
+	schemaDiff := diff.Compute(oldFile, newFile, renameMap)
+	upSQL, downSQL := diff.Render(schemaDiff, selectedDialect, *down != "")
+
+	if err := saveToFile(upSQL, *out); err != nil {
+		return err
+	}
+	if *down != "" {
+		if err := saveToFile(downSQL, *down); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Migration saved to %s successfully.\n", *out)
 	return nil
 }
 
-func generateCreateTableSQL(table Table) string {
+// parseRenameMap parses a comma-separated list of "table.old=new" pairs
+// into the map form diff.Compute expects (keyed by "table.old").
+func parseRenameMap(raw string) (map[string]string, error) {
+	renameMap := map[string]string{}
+	if raw == "" {
+		return renameMap, nil
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid --rename-map entry %q, expected table.old=new", pair)
+		}
+		renameMap[parts[0]] = parts[1]
+	}
+	return renameMap, nil
+}
+
+func generateCreateTableSQL(table Table, dialect Dialect) (string, error) {
 	var fieldsSQL []string
+	soleKeyFolded := false
 	for _, field := range table.Fields {
-		fieldSQL := fmt.Sprintf("%s %s", field.Name, field.Type)
-		if field.Length != -2147483648 {
-			fieldSQL += fmt.Sprintf("(%d", field.Length)
-			if field.Decimals != -2147483648 {
-				fieldSQL += fmt.Sprintf(",%d)", field.Decimals)
-			} else {
-				fieldSQL += ")"
-			}
+		fieldSQL, err := generateColumnDefinitionSQL(table, field, dialect)
+		if err != nil {
+			return "", fmt.Errorf("table %s, column %s: %w", table.Name, field.Name, err)
 		}
-		if !field.IsNullable {
-			fieldSQL += " NOT NULL"
-		}
-		if field.DefaultType != "None" {
-			defaultValueString := field.DefaultValue
-			if field.DefaultType == "Expression" {
-				defaultValueString = fmt.Sprintf("'%s'", field.DefaultValue)
-			}
-			fieldSQL += fmt.Sprintf(" DEFAULT %s", defaultValueString)
+		if isSinglePrimaryKeyField(table, field) && isIntegerFieldType(field) && field.Computed == "" {
+			soleKeyFolded = true
 		}
 		fieldsSQL = append(fieldsSQL, fieldSQL)
 	}
-	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);\n\n", table.Name, strings.Join(fieldsSQL, ",\n  "))
+
+	// Dialects that can't ALTER TABLE ... ADD CONSTRAINT (SQLite) must
+	// have their primary key and foreign keys declared inline here
+	// instead; a single-column integer primary key is instead folded into
+	// its column definition above via AutoIncrementClause.
+	if !dialect.SupportsAlterConstraints() {
+		if len(table.PrimaryKey.Fields) > 1 || (len(table.PrimaryKey.Fields) == 1 && !soleKeyFolded) {
+			fieldsSQL = append(fieldsSQL, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(quoteIdentifiers(dialect, table.PrimaryKey.Fields), ", ")))
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			fieldsSQL = append(fieldsSQL, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+				strings.Join(quoteIdentifiers(dialect, foreignKey.Fields), ", "),
+				dialect.QuoteIdentifier(foreignKey.ReferenceTable),
+				strings.Join(quoteIdentifiers(dialect, foreignKey.ReferenceFields), ", ")))
+		}
+	}
+
+	var checksSQL []string
+	for _, check := range table.CheckConstraints {
+		rendered, err := expr.EmitString(check.Expression, dialect)
+		if err != nil {
+			return "", fmt.Errorf("table %s, check %s: %w", table.Name, check.Name, err)
+		}
+		checksSQL = append(checksSQL, fmt.Sprintf("CONSTRAINT %s CHECK (%s)", dialect.QuoteIdentifier(check.Name), rendered))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);\n\n", dialect.QuoteIdentifier(table.Name), strings.Join(append(fieldsSQL, checksSQL...), ",\n  ")), nil
 }
 
-func generateCreatePrimaryKeySQL(table Table) string {
-	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT pk_%s PRIMARY KEY (%s);\n\n", table.Name, table.Name, strings.Join(table.PrimaryKey.Fields, ", "))
+func generateColumnDefinitionSQL(table Table, field TableField, dialect Dialect) (string, error) {
+	isSoleKeyField := isSinglePrimaryKeyField(table, field)
+	fieldSQL := fmt.Sprintf("%s %s", dialect.QuoteIdentifier(field.Name), dialect.ColumnType(field, isSoleKeyField))
+
+	if field.Computed != "" {
+		computedSQL, err := expr.EmitString(field.Computed, dialect)
+		if err != nil {
+			return "", err
+		}
+		fieldSQL += " " + dialect.ComputedColumnClause(computedSQL)
+		return fieldSQL, nil
+	}
+
+	if !field.IsNullable {
+		fieldSQL += " NOT NULL"
+	}
+	if field.DefaultType != "None" {
+		fieldSQL += fmt.Sprintf(" DEFAULT %s", dialect.RenderDefault(field))
+	}
+	if isSoleKeyField && isIntegerFieldType(field) && dialect.AutoIncrementClause() != "" {
+		fieldSQL += " " + dialect.AutoIncrementClause()
+	}
+	return fieldSQL, nil
 }
 
-func generateCreateForeignKeySQL(table Table) string {
+// isSinglePrimaryKeyField reports whether field is the table's sole
+// primary-key field, the case in which an AutoIncrementClause applies.
+func isSinglePrimaryKeyField(table Table, field TableField) bool {
+	return len(table.PrimaryKey.Fields) == 1 && table.PrimaryKey.Fields[0] == field.Name
+}
+
+// isIntegerFieldType reports whether field's declared type is one an
+// auto-increment clause (AUTO_INCREMENT, IDENTITY(1,1), AUTOINCREMENT, ...)
+// is meaningful on. A sole-PK field of any other type (varchar, uuid, ...)
+// must not get one: every engine rejects AUTO_INCREMENT/IDENTITY/
+// AUTOINCREMENT on a non-integer column.
+func isIntegerFieldType(field TableField) bool {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer", "bigint":
+		return true
+	default:
+		return false
+	}
+}
+
+// generateCreatePrimaryKeySQL returns the ALTER TABLE statement adding
+// table's primary key constraint. Dialects that can't express this via
+// ALTER TABLE (SQLite) declare it inline in CREATE TABLE instead (see
+// generateCreateTableSQL), so there is nothing to add here.
+func generateCreatePrimaryKeySQL(table Table, dialect Dialect) string {
+	if !dialect.SupportsAlterConstraints() {
+		return ""
+	}
+	quotedFields := quoteIdentifiers(dialect, table.PrimaryKey.Fields)
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);\n\n", dialect.QuoteIdentifier(table.Name), dialect.QuoteIdentifier("pk_"+table.Name), strings.Join(quotedFields, ", "))
+}
+
+// generateCreateForeignKeySQL returns the ALTER TABLE statements adding
+// table's foreign keys. Dialects that can't express this via ALTER TABLE
+// (SQLite) declare them inline in CREATE TABLE instead (see
+// generateCreateTableSQL), so there is nothing to add here.
+func generateCreateForeignKeySQL(table Table, dialect Dialect) string {
+	if !dialect.SupportsAlterConstraints() {
+		return ""
+	}
 	var sql strings.Builder
 	for _, foreignKey := range table.ForeignKeys {
-		fmt.Fprintf(&sql, "ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s);\n\n", table.Name, foreignKey.Name, strings.Join(foreignKey.Fields, ", "), foreignKey.ReferenceTable, strings.Join(foreignKey.ReferenceFields, ", "))
+		fmt.Fprintf(&sql, "ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s);\n\n",
+			dialect.QuoteIdentifier(table.Name),
+			dialect.QuoteIdentifier(foreignKey.Name),
+			strings.Join(quoteIdentifiers(dialect, foreignKey.Fields), ", "),
+			dialect.QuoteIdentifier(foreignKey.ReferenceTable),
+			strings.Join(quoteIdentifiers(dialect, foreignKey.ReferenceFields), ", "))
 	}
 	return sql.String()
 }
 
-func generateCreateIndexSQL(table Table) string {
-	return fmt.Sprintf("CREATE INDEX idx_%s_id ON %s (%s);\n\n", table.Name, table.Name, strings.Join(table.PrimaryKey.Fields, ", "))
+func generateCreateIndexSQL(table Table, dialect Dialect) string {
+	return fmt.Sprintf("CREATE INDEX %s ON %s (%s);\n\n", dialect.QuoteIdentifier(fmt.Sprintf("idx_%s_id", table.Name)), dialect.QuoteIdentifier(table.Name), strings.Join(quoteIdentifiers(dialect, table.PrimaryKey.Fields), ", "))
 }
 
-func generateCreateIndexForForeignKeySQL(table Table) string {
+func generateCreateIndexForForeignKeySQL(table Table, dialect Dialect) string {
 	var sql strings.Builder
 	for _, foreignKey := range table.ForeignKeys {
-		fmt.Fprintf(&sql, "CREATE INDEX idx_fk_%s_%s ON %s (%s);\n\n", table.Name, foreignKey.ReferenceTable, table.Name, strings.Join(foreignKey.Fields, ", "))
+		fmt.Fprintf(&sql, "CREATE INDEX %s ON %s (%s);\n\n",
+			dialect.QuoteIdentifier(fmt.Sprintf("idx_fk_%s_%s", table.Name, foreignKey.ReferenceTable)),
+			dialect.QuoteIdentifier(table.Name),
+			strings.Join(quoteIdentifiers(dialect, foreignKey.Fields), ", "))
 	}
 	return sql.String()
 }
 
-func generateSQLFromNDM2File(file NDM2File) string {
+// quoteIdentifiers applies dialect's quoting to each name in names.
+func quoteIdentifiers(dialect Dialect, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = dialect.QuoteIdentifier(name)
+	}
+	return quoted
+}
+
+func generateSQLFromNDM2File(file NDM2File, dialect Dialect) (string, error) {
 	var sql strings.Builder
 
 	for _, catalog := range file.Server.Catalogs {
@@ -136,27 +389,31 @@ func generateSQLFromNDM2File(file NDM2File) string {
 			tables := schema.Tables
 
 			for _, table := range tables {
-				sql.WriteString(generateCreateTableSQL(table))
+				createTableSQL, err := generateCreateTableSQL(table, dialect)
+				if err != nil {
+					return "", err
+				}
+				sql.WriteString(createTableSQL)
 			}
 
 			for _, table := range tables {
-				sql.WriteString(generateCreatePrimaryKeySQL(table))
+				sql.WriteString(generateCreatePrimaryKeySQL(table, dialect))
 			}
 
 			for _, table := range tables {
-				sql.WriteString(generateCreateForeignKeySQL(table))
+				sql.WriteString(generateCreateForeignKeySQL(table, dialect))
 			}
 
 			for _, table := range tables {
-				sql.WriteString(generateCreateIndexSQL(table))
+				sql.WriteString(generateCreateIndexSQL(table, dialect))
 			}
 
 			for _, table := range tables {
-				sql.WriteString(generateCreateIndexForForeignKeySQL(table))
+				sql.WriteString(generateCreateIndexForForeignKeySQL(table, dialect))
 			}
 		}
 	}
-	return sql.String()
+	return sql.String(), nil
 }
 
 func parseFile(filePath string) (NDM2File, error) {
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/willianricardo/ndm2sql/cmd/dialect"
+)
+
+func TestGenerateColumnDefinitionSQLSkipsAutoIncrementForNonIntegerPrimaryKey(t *testing.T) {
+	table := Table{
+		Name:       "widgets",
+		PrimaryKey: PrimaryKey{Fields: []string{"code"}},
+		Fields:     []TableField{{Name: "code", Type: "varchar", Length: 36, DefaultType: "None"}},
+	}
+	field := table.Fields[0]
+
+	for _, name := range []string{"mysql", "postgres", "mssql", "sqlite", "oracle"} {
+		d, err := dialect.Resolve(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sql, err := generateColumnDefinitionSQL(table, field, d)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, clause := range []string{"AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY", "SERIAL"} {
+			if strings.Contains(sql, clause) {
+				t.Errorf("%s: expected no auto-increment clause on a varchar primary key, got %q", name, sql)
+			}
+		}
+	}
+}
+
+func TestGenerateColumnDefinitionSQLAppliesAutoIncrementForIntegerPrimaryKey(t *testing.T) {
+	table := Table{
+		Name:       "widgets",
+		PrimaryKey: PrimaryKey{Fields: []string{"id"}},
+		Fields:     []TableField{{Name: "id", Type: "int", DefaultType: "None"}},
+	}
+	field := table.Fields[0]
+
+	cases := map[string]string{
+		"mysql":    "AUTO_INCREMENT",
+		"mssql":    "IDENTITY(1,1)",
+		"sqlite":   "AUTOINCREMENT",
+		"postgres": "SERIAL",
+	}
+	for name, want := range cases {
+		d, err := dialect.Resolve(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sql, err := generateColumnDefinitionSQL(table, field, d)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !strings.Contains(sql, want) {
+			t.Errorf("%s: expected %q in %q", name, want, sql)
+		}
+	}
+}
+
+func TestReorderFlagsFirstSupportsPositionalArgsBeforeFlags(t *testing.T) {
+	valuedFlags := map[string]bool{"dialect": true, "out": true}
+
+	got := reorderFlagsFirst([]string{"old.ndm2", "new.ndm2", "--dialect", "postgres", "--out", "mig.sql"}, valuedFlags)
+	want := []string{"--dialect", "postgres", "--out", "mig.sql", "old.ndm2", "new.ndm2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestReorderFlagsFirstLeavesAlreadyOrderedArgsAlone(t *testing.T) {
+	valuedFlags := map[string]bool{"dialect": true, "out": true}
+
+	got := reorderFlagsFirst([]string{"--dialect", "postgres", "--out", "mig.sql", "old.ndm2", "new.ndm2"}, valuedFlags)
+	want := []string{"--dialect", "postgres", "--out", "mig.sql", "old.ndm2", "new.ndm2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestReorderFlagsFirstHandlesEqualsForm(t *testing.T) {
+	valuedFlags := map[string]bool{"dialect": true}
+
+	got := reorderFlagsFirst([]string{"old.ndm2", "--dialect=postgres", "new.ndm2"}, valuedFlags)
+	want := []string{"--dialect=postgres", "old.ndm2", "new.ndm2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
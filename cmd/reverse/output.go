@@ -0,0 +1,17 @@
+package reverse
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// writeNDM2File marshals file as indented JSON and writes it to path.
+func writeNDM2File(file schema.NDM2File, path string) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
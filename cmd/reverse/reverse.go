@@ -0,0 +1,321 @@
+// Package reverse implements "ndm2sql reverse": introspecting a live
+// database via information_schema and emitting an NDM2File describing its
+// schema, the mirror image of the SQL-generation path in cmd.
+package reverse
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+// unspecified is the NDM2 sentinel used for a field's Length/Decimals when
+// the column type carries no such value (e.g. TEXT, BOOLEAN).
+const unspecified = -2147483648
+
+// Config describes a single reverse-engineering run.
+type Config struct {
+	Driver string // "mysql" or "postgres"
+	DSN    string
+	Out    string
+}
+
+// columnTypeMapper maps a driver's native column type name to an NDM2
+// field type, registered per driver in typeMappers.
+type columnTypeMapper func(dataType string) string
+
+var typeMappers = map[string]columnTypeMapper{
+	"mysql":    mysqlColumnType,
+	"postgres": postgresColumnType,
+}
+
+// driverQueries holds the introspection query bodies that differ between
+// drivers: placeholder style (lib/pq only accepts $1-style positional
+// parameters, not go-sql-driver/mysql's ?) and, for foreign keys, the
+// join shape itself (MySQL's key_column_usage carries
+// referenced_table_name/referenced_column_name directly; Postgres has no
+// such columns and must join through constraint_column_usage instead).
+type driverQueries struct {
+	columns     string
+	primaryKey  string
+	foreignKeys string
+}
+
+var queriesByDriver = map[string]driverQueries{
+	"mysql": {
+		columns: `
+			SELECT column_name, data_type, is_nullable, character_maximum_length,
+			       numeric_precision, numeric_scale, column_default
+			FROM information_schema.columns
+			WHERE table_name = ?
+			ORDER BY ordinal_position`,
+		primaryKey: `
+			SELECT kcu.constraint_name, kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+			  ON tc.constraint_name = kcu.constraint_name AND tc.table_name = kcu.table_name
+			WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_name = ?
+			ORDER BY kcu.ordinal_position`,
+		foreignKeys: `
+			SELECT tc.constraint_name, kcu.column_name, kcu.referenced_table_name, kcu.referenced_column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+			  ON tc.constraint_name = kcu.constraint_name AND tc.table_name = kcu.table_name
+			WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name = ?
+			ORDER BY kcu.ordinal_position`,
+	},
+	"postgres": {
+		columns: `
+			SELECT column_name, data_type, is_nullable, character_maximum_length,
+			       numeric_precision, numeric_scale, column_default
+			FROM information_schema.columns
+			WHERE table_name = $1
+			ORDER BY ordinal_position`,
+		primaryKey: `
+			SELECT kcu.constraint_name, kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+			  ON tc.constraint_name = kcu.constraint_name AND tc.table_name = kcu.table_name
+			WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_name = $1
+			ORDER BY kcu.ordinal_position`,
+		// Postgres's key_column_usage has no referenced_table_name/
+		// referenced_column_name (that's a MySQL-only extension); the
+		// referenced side has to come from constraint_column_usage
+		// instead. Note this join does not reliably preserve
+		// column-to-column order for composite foreign keys (a known
+		// information_schema limitation), only for single-column ones.
+		foreignKeys: `
+			SELECT tc.constraint_name, kcu.column_name, ccu.table_name AS referenced_table_name, ccu.column_name AS referenced_column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+			  ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+			JOIN information_schema.constraint_column_usage ccu
+			  ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+			WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name = $1
+			ORDER BY kcu.ordinal_position`,
+	},
+}
+
+func mysqlColumnType(dataType string) string {
+	switch dataType {
+	case "int", "tinyint", "smallint", "mediumint", "bigint":
+		return "int"
+	case "decimal", "numeric":
+		return "decimal"
+	case "varchar", "char":
+		return "varchar"
+	case "text", "longtext", "mediumtext":
+		return "text"
+	case "datetime", "timestamp":
+		return "datetime"
+	case "date":
+		return "date"
+	default:
+		return dataType
+	}
+}
+
+func postgresColumnType(dataType string) string {
+	switch dataType {
+	case "integer", "smallint", "bigint":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "numeric":
+		return "decimal"
+	case "character varying", "character":
+		return "varchar"
+	case "text":
+		return "text"
+	case "timestamp without time zone", "timestamp with time zone":
+		return "datetime"
+	case "date":
+		return "date"
+	default:
+		return dataType
+	}
+}
+
+// Run connects to the database described by cfg, introspects its schema
+// and writes the resulting NDM2File as JSON to cfg.Out.
+func Run(cfg Config) error {
+	mapColumnType, ok := typeMappers[cfg.Driver]
+	if !ok {
+		return fmt.Errorf("unsupported driver %q (valid: mysql, postgres)", cfg.Driver)
+	}
+	queries := queriesByDriver[cfg.Driver]
+
+	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer db.Close()
+
+	tableNames, err := getTableNames(db)
+	if err != nil {
+		return fmt.Errorf("listing tables: %w", err)
+	}
+
+	var tables []schema.Table
+	for _, tableName := range tableNames {
+		fields, err := getColumns(db, queries.columns, tableName, mapColumnType)
+		if err != nil {
+			return fmt.Errorf("introspecting columns of %s: %w", tableName, err)
+		}
+
+		primaryKey, err := getPrimaryKey(db, queries.primaryKey, tableName)
+		if err != nil {
+			return fmt.Errorf("introspecting primary key of %s: %w", tableName, err)
+		}
+
+		foreignKeys, err := getForeignKeys(db, queries.foreignKeys, tableName)
+		if err != nil {
+			return fmt.Errorf("introspecting foreign keys of %s: %w", tableName, err)
+		}
+
+		tables = append(tables, schema.Table{
+			Name:        tableName,
+			Fields:      fields,
+			PrimaryKey:  primaryKey,
+			ForeignKeys: foreignKeys,
+		})
+	}
+
+	file := schema.NDM2File{
+		Server: schema.Server{
+			Catalogs: []schema.Catalog{
+				{
+					Schemas: []schema.Schema{
+						{Tables: tables},
+					},
+				},
+			},
+		},
+	}
+
+	return writeNDM2File(file, cfg.Out)
+}
+
+func getTableNames(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT table_name FROM information_schema.tables WHERE table_schema NOT IN ('information_schema', 'pg_catalog')`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func getColumns(db *sql.DB, query, tableName string, mapColumnType columnTypeMapper) ([]schema.TableField, error) {
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []schema.TableField
+	for rows.Next() {
+		var (
+			columnName       string
+			dataType         string
+			isNullable       string
+			characterMaxLen  sql.NullInt64
+			numericPrecision sql.NullInt64
+			numericScale     sql.NullInt64
+			columnDefault    sql.NullString
+		)
+		if err := rows.Scan(&columnName, &dataType, &isNullable, &characterMaxLen, &numericPrecision, &numericScale, &columnDefault); err != nil {
+			return nil, err
+		}
+
+		field := schema.TableField{
+			Name:       columnName,
+			Type:       mapColumnType(dataType),
+			Length:     unspecified,
+			Decimals:   unspecified,
+			IsNullable: isNullable == "YES",
+		}
+		if characterMaxLen.Valid {
+			field.Length = int(characterMaxLen.Int64)
+		} else if numericPrecision.Valid {
+			field.Length = int(numericPrecision.Int64)
+		}
+		if numericScale.Valid {
+			field.Decimals = int(numericScale.Int64)
+		}
+		if columnDefault.Valid {
+			field.DefaultType = "Literal"
+			field.DefaultValue = columnDefault.String
+		} else {
+			field.DefaultType = "None"
+		}
+
+		fields = append(fields, field)
+	}
+	return fields, rows.Err()
+}
+
+func getPrimaryKey(db *sql.DB, query, tableName string) (schema.PrimaryKey, error) {
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return schema.PrimaryKey{}, err
+	}
+	defer rows.Close()
+
+	var primaryKey schema.PrimaryKey
+	for rows.Next() {
+		var name, column string
+		if err := rows.Scan(&name, &column); err != nil {
+			return schema.PrimaryKey{}, err
+		}
+		primaryKey.Name = name
+		primaryKey.Fields = append(primaryKey.Fields, column)
+	}
+	return primaryKey, rows.Err()
+}
+
+func getForeignKeys(db *sql.DB, query, tableName string) ([]schema.ForeignKey, error) {
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byName := map[string]*schema.ForeignKey{}
+	var order []string
+	for rows.Next() {
+		var name, column, refTable, refColumn string
+		if err := rows.Scan(&name, &column, &refTable, &refColumn); err != nil {
+			return nil, err
+		}
+		fk, ok := byName[name]
+		if !ok {
+			fk = &schema.ForeignKey{Name: name, ReferenceTable: refTable}
+			byName[name] = fk
+			order = append(order, name)
+		}
+		fk.Fields = append(fk.Fields, column)
+		fk.ReferenceFields = append(fk.ReferenceFields, refColumn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	foreignKeys := make([]schema.ForeignKey, 0, len(order))
+	for _, name := range order {
+		foreignKeys = append(foreignKeys, *byName[name])
+	}
+	return foreignKeys, nil
+}
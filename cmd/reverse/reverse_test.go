@@ -0,0 +1,117 @@
+package reverse
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestGetColumnsMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	queries := queriesByDriver["mysql"]
+	rows := sqlmock.NewRows([]string{"column_name", "data_type", "is_nullable", "character_maximum_length", "numeric_precision", "numeric_scale", "column_default"}).
+		AddRow("id", "int", "NO", nil, nil, nil, nil).
+		AddRow("name", "varchar", "YES", 50, nil, nil, "anonymous")
+	mock.ExpectQuery(regexp.QuoteMeta(queries.columns)).WithArgs("users").WillReturnRows(rows)
+
+	fields, err := getColumns(db, queries.columns, "users", mysqlColumnType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d: %+v", len(fields), fields)
+	}
+	if fields[0].Name != "id" || fields[0].Type != "int" || fields[0].IsNullable {
+		t.Fatalf("unexpected id field: %+v", fields[0])
+	}
+	if fields[1].Name != "name" || fields[1].Length != 50 || fields[1].DefaultType != "Literal" || fields[1].DefaultValue != "anonymous" {
+		t.Fatalf("unexpected name field: %+v", fields[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetPrimaryKeyPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	queries := queriesByDriver["postgres"]
+	rows := sqlmock.NewRows([]string{"constraint_name", "column_name"}).
+		AddRow("users_pkey", "id")
+	mock.ExpectQuery(regexp.QuoteMeta(queries.primaryKey)).WithArgs("users").WillReturnRows(rows)
+
+	pk, err := getPrimaryKey(db, queries.primaryKey, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.Name != "users_pkey" || len(pk.Fields) != 1 || pk.Fields[0] != "id" {
+		t.Fatalf("unexpected primary key: %+v", pk)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetForeignKeysMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	queries := queriesByDriver["mysql"]
+	rows := sqlmock.NewRows([]string{"constraint_name", "column_name", "referenced_table_name", "referenced_column_name"}).
+		AddRow("fk_orders_user", "user_id", "users", "id")
+	mock.ExpectQuery(regexp.QuoteMeta(queries.foreignKeys)).WithArgs("orders").WillReturnRows(rows)
+
+	fks, err := getForeignKeys(db, queries.foreignKeys, "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fks) != 1 || fks[0].Name != "fk_orders_user" || fks[0].ReferenceTable != "users" {
+		t.Fatalf("unexpected foreign keys: %+v", fks)
+	}
+	if len(fks[0].Fields) != 1 || fks[0].Fields[0] != "user_id" {
+		t.Fatalf("unexpected foreign key fields: %+v", fks[0])
+	}
+	if len(fks[0].ReferenceFields) != 1 || fks[0].ReferenceFields[0] != "id" {
+		t.Fatalf("unexpected foreign key reference fields: %+v", fks[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetForeignKeysPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	queries := queriesByDriver["postgres"]
+	rows := sqlmock.NewRows([]string{"constraint_name", "column_name", "referenced_table_name", "referenced_column_name"}).
+		AddRow("fk_orders_user", "user_id", "users", "id")
+	mock.ExpectQuery(regexp.QuoteMeta(queries.foreignKeys)).WithArgs("orders").WillReturnRows(rows)
+
+	fks, err := getForeignKeys(db, queries.foreignKeys, "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fks) != 1 || fks[0].Name != "fk_orders_user" || fks[0].ReferenceTable != "users" {
+		t.Fatalf("unexpected foreign keys: %+v", fks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,63 @@
+// Package schema defines the NDM2 document model shared by every
+// subcommand: the SQL generator, the reverse-engineering introspector and
+// the diff engine all read and write these same types.
+package schema
+
+type PrimaryKey struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+type ForeignKey struct {
+	Name            string   `json:"name"`
+	Fields          []string `json:"fields"`
+	ReferenceTable  string   `json:"referenceTable"`
+	ReferenceFields []string `json:"referenceFields"`
+}
+
+type TableField struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Length       int    `json:"length"`
+	Decimals     int    `json:"decimals"`
+	IsNullable   bool   `json:"isNullable"`
+	DefaultType  string `json:"defaultType"`
+	DefaultValue string `json:"defaultValue"`
+	// Computed, when non-empty, is an expression (parsed and rendered by
+	// cmd/expr) that makes this a computed/generated column instead of a
+	// stored one; DefaultType/DefaultValue do not apply in that case.
+	Computed string `json:"computed,omitempty"`
+}
+
+// CheckConstraint is a table-level CHECK constraint whose Expression is
+// parsed and rendered by cmd/expr.
+type CheckConstraint struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+type Table struct {
+	Name             string            `json:"name"`
+	Fields           []TableField      `json:"fields"`
+	PrimaryKey       PrimaryKey        `json:"primaryKey"`
+	ForeignKeys      []ForeignKey      `json:"foreignKeys"`
+	CheckConstraints []CheckConstraint `json:"checkConstraints,omitempty"`
+}
+
+type Schema struct {
+	Name   string  `json:"name"`
+	Tables []Table `json:"tables"`
+}
+
+type Catalog struct {
+	Name    string   `json:"name"`
+	Schemas []Schema `json:"schemas"`
+}
+
+type Server struct {
+	Catalogs []Catalog `json:"catalogs"`
+}
+
+type NDM2File struct {
+	Server Server `json:"server"`
+}
@@ -0,0 +1,66 @@
+package schema
+
+// SortTablesByDependency returns tables reordered so that, as far as the
+// foreign keys between them allow, a table is never emitted before every
+// other table in tables that it has a foreign key referencing. This lets
+// callers emit CREATE TABLE/ADD FOREIGN KEY statements in a single pass
+// per table without a referenced table coming later in the output.
+//
+// Ties (tables with no ordering constraint between them) keep their
+// original relative order. A foreign key cycle, or a foreign key to a
+// table not present in tables, can't be linearized; affected tables are
+// appended in their original relative order once no more progress can be
+// made, rather than erroring.
+func SortTablesByDependency(tables []Table) []Table {
+	index := make(map[string]int, len(tables))
+	for i, table := range tables {
+		index[table.Name] = i
+	}
+
+	dependsOn := make([][]int, len(tables))
+	for i, table := range tables {
+		seen := make(map[int]bool)
+		for _, fk := range table.ForeignKeys {
+			if j, ok := index[fk.ReferenceTable]; ok && j != i && !seen[j] {
+				dependsOn[i] = append(dependsOn[i], j)
+				seen[j] = true
+			}
+		}
+	}
+
+	emitted := make([]bool, len(tables))
+	result := make([]Table, 0, len(tables))
+	for len(result) < len(tables) {
+		progressed := false
+		for i, table := range tables {
+			if emitted[i] {
+				continue
+			}
+			ready := true
+			for _, dep := range dependsOn[i] {
+				if !emitted[dep] {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+			result = append(result, table)
+			emitted[i] = true
+			progressed = true
+		}
+		if !progressed {
+			// A cycle (or a dependency on a table outside tables):
+			// append whatever remains, in original order, since it
+			// can't be linearized any further.
+			for i, table := range tables {
+				if !emitted[i] {
+					result = append(result, table)
+					emitted[i] = true
+				}
+			}
+		}
+	}
+	return result
+}
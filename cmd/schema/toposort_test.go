@@ -0,0 +1,48 @@
+package schema
+
+import "testing"
+
+func TestSortTablesByDependencyOrdersReferencedTableFirst(t *testing.T) {
+	orders := Table{
+		Name:        "orders",
+		ForeignKeys: []ForeignKey{{Name: "fk_orders_customer", Fields: []string{"customer_id"}, ReferenceTable: "customers", ReferenceFields: []string{"id"}}},
+	}
+	customers := Table{Name: "customers"}
+
+	sorted := SortTablesByDependency([]Table{orders, customers})
+
+	if len(sorted) != 2 || sorted[0].Name != "customers" || sorted[1].Name != "orders" {
+		t.Fatalf("expected [customers, orders], got %+v", tableNames(sorted))
+	}
+}
+
+func TestSortTablesByDependencyPreservesOrderWithoutConstraints(t *testing.T) {
+	a := Table{Name: "a"}
+	b := Table{Name: "b"}
+	c := Table{Name: "c"}
+
+	sorted := SortTablesByDependency([]Table{a, b, c})
+
+	if got := tableNames(sorted); got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("expected original order preserved, got %v", got)
+	}
+}
+
+func TestSortTablesByDependencyToleratesCycles(t *testing.T) {
+	a := Table{Name: "a", ForeignKeys: []ForeignKey{{Name: "fk_a_b", Fields: []string{"b_id"}, ReferenceTable: "b", ReferenceFields: []string{"id"}}}}
+	b := Table{Name: "b", ForeignKeys: []ForeignKey{{Name: "fk_b_a", Fields: []string{"a_id"}, ReferenceTable: "a", ReferenceFields: []string{"id"}}}}
+
+	sorted := SortTablesByDependency([]Table{a, b})
+
+	if len(sorted) != 2 {
+		t.Fatalf("expected both tables present despite the cycle, got %+v", tableNames(sorted))
+	}
+}
+
+func tableNames(tables []Table) []string {
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+	return names
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/willianricardo/ndm2sql/cmd/schema"
+)
+
+// streamSizeThreshold is the input size above which --stream=auto enables
+// the streaming code path.
+const streamSizeThreshold = 64 * 1024 * 1024 // 64MB
+
+// resolveStreamMode interprets the --stream flag value ("auto", "true" or
+// "false") against the input file's size.
+func resolveStreamMode(streamFlag string, inputFilePath string) (bool, error) {
+	switch streamFlag {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "auto":
+		info, err := os.Stat(inputFilePath)
+		if err != nil {
+			return false, err
+		}
+		return info.Size() > streamSizeThreshold, nil
+	default:
+		return false, fmt.Errorf("invalid --stream value %q (valid: auto, true, false)", streamFlag)
+	}
+}
+
+// generateSQLToFileStreamed streams SQL generation from inputFilePath
+// straight to outputFilePath, never holding the parsed document or the
+// full rendered output in memory at once.
+func generateSQLToFileStreamed(inputFilePath, outputFilePath string, dialect Dialect) error {
+	in, err := os.Open(inputFilePath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(outputFilePath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	return generateSQLStreamed(in, out, dialect)
+}
+
+// generateSQLStreamed walks an NDM2 document token-by-token, emitting SQL
+// for each table directly to w as soon as that table is decoded, instead
+// of buffering the whole parsed document (parseFile) and the whole
+// rendered output (generateSQLFromNDM2File) in memory. Because each
+// table's statements are written as it arrives, CREATE/PK/FK/index
+// statements for a table are grouped together rather than batched by
+// statement kind across the whole schema, unlike generateSQLFromNDM2File.
+//
+// This means a table's FOREIGN KEY statements are emitted immediately
+// alongside its CREATE TABLE, so --stream requires the input file to
+// already list every table after the tables its foreign keys reference
+// (the in-memory paths have no such requirement: generateSQLFromNDM2File
+// batches all CREATE TABLEs before any ALTER TABLE ... ADD FOREIGN KEY,
+// and generateSQLFromNDM2FileCached reorders tables with
+// schema.SortTablesByDependency first). Lifting this in general would mean
+// buffering every table to topologically sort them first, which defeats
+// the point of streaming, so tables are emitted in input order.
+func generateSQLStreamed(r io.Reader, w io.Writer, dialect Dialect) error {
+	dec := json.NewDecoder(r)
+	return walkNDM2File(dec, func(table schema.Table) error {
+		createTableSQL, err := generateCreateTableSQL(table, dialect)
+		if err != nil {
+			return err
+		}
+		for _, sql := range []string{
+			createTableSQL,
+			generateCreatePrimaryKeySQL(table, dialect),
+			generateCreateForeignKeySQL(table, dialect),
+			generateCreateIndexSQL(table, dialect),
+			generateCreateIndexForForeignKeySQL(table, dialect),
+		} {
+			if _, err := io.WriteString(w, sql); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// walkNDM2File decodes an NDM2File from dec without ever holding the full
+// document in memory: it walks server.catalogs[*].schemas[*].tables[*]
+// token-by-token and decodes one Table at a time, invoking onTable for
+// each. Every other field is decoded into a throwaway json.RawMessage so
+// unrelated document shape changes don't break the walk.
+func walkNDM2File(dec *json.Decoder, onTable func(schema.Table) error) error {
+	return decodeObjectFields(dec, func(key string) error {
+		if key != "server" {
+			return skipValue(dec)
+		}
+		return decodeObjectFields(dec, func(key string) error {
+			if key != "catalogs" {
+				return skipValue(dec)
+			}
+			return decodeArrayElements(dec, func() error {
+				return decodeObjectFields(dec, func(key string) error {
+					if key != "schemas" {
+						return skipValue(dec)
+					}
+					return decodeArrayElements(dec, func() error {
+						return decodeObjectFields(dec, func(key string) error {
+							if key != "tables" {
+								return skipValue(dec)
+							}
+							return decodeArrayElements(dec, func() error {
+								var table schema.Table
+								if err := dec.Decode(&table); err != nil {
+									return err
+								}
+								return onTable(table)
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+// decodeObjectFields reads the '{', then repeatedly reads a field name
+// and invokes handler(name) to consume that field's value, until the
+// matching '}'.
+func decodeObjectFields(dec *json.Decoder, handler func(name string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected field name, got %v", tok)
+		}
+		if err := handler(name); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // consume '}'
+	return err
+}
+
+// decodeArrayElements reads the '[', then invokes each() once per element
+// (each is responsible for consuming exactly one element), until the
+// matching ']'.
+func decodeArrayElements(dec *json.Decoder, each func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+	for dec.More() {
+		if err := each(); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // consume ']'
+	return err
+}
+
+// skipValue consumes whatever value dec is positioned at (object, array
+// or scalar) without decoding it into anything useful.
+func skipValue(dec *json.Decoder) error {
+	var raw json.RawMessage
+	return dec.Decode(&raw)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/willianricardo/ndm2sql/cmd/dialect"
+)
+
+// syntheticNDM2File builds an NDM2File with tableCount tables, each with
+// fieldsPerTable plain integer columns and no foreign keys, so the two
+// generation paths' differing statement-grouping order doesn't affect
+// which CREATE TABLE statements are produced.
+func syntheticNDM2File(tableCount, fieldsPerTable int) NDM2File {
+	tables := make([]Table, tableCount)
+	for i := 0; i < tableCount; i++ {
+		fields := make([]TableField, fieldsPerTable)
+		for f := 0; f < fieldsPerTable; f++ {
+			fields[f] = TableField{Name: fmt.Sprintf("col_%d", f), Type: "int", DefaultType: "None"}
+		}
+		tables[i] = Table{Name: fmt.Sprintf("table_%d", i), Fields: fields}
+	}
+	return NDM2File{Server: Server{Catalogs: []Catalog{{Schemas: []Schema{{Tables: tables}}}}}}
+}
+
+func TestGenerateSQLStreamedMatchesInMemoryCreateStatements(t *testing.T) {
+	file := syntheticNDM2File(20, 3)
+	d, err := dialect.Resolve(dialect.DefaultName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inMemorySQL, err := generateSQLFromNDM2File(file, d)
+	if err != nil {
+		t.Fatalf("generateSQLFromNDM2File: %v", err)
+	}
+
+	encoded, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var streamedSQL bytes.Buffer
+	if err := generateSQLStreamed(bytes.NewReader(encoded), &streamedSQL, d); err != nil {
+		t.Fatalf("generateSQLStreamed: %v", err)
+	}
+
+	for _, table := range file.Server.Catalogs[0].Schemas[0].Tables {
+		createTableSQL, err := generateCreateTableSQL(table, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(inMemorySQL, createTableSQL) {
+			t.Fatalf("in-memory output missing CREATE TABLE for %s", table.Name)
+		}
+		if !strings.Contains(streamedSQL.String(), createTableSQL) {
+			t.Fatalf("streamed output missing CREATE TABLE for %s", table.Name)
+		}
+	}
+}
+
+// BenchmarkGenerateSQLInMemory and BenchmarkGenerateSQLStreamed compare the
+// two generation paths' cost on the same synthetic schema, both starting
+// from the same encoded JSON: the in-memory path parses the whole document
+// into an NDM2File and renders the whole output into one string, while the
+// streamed path decodes and renders one table at a time. The table/field
+// counts below keep `go test -bench` fast.
+const (
+	benchTableCount     = 2000
+	benchFieldsPerTable = 8
+)
+
+func BenchmarkGenerateSQLInMemory(b *testing.B) {
+	file := syntheticNDM2File(benchTableCount, benchFieldsPerTable)
+	d, err := dialect.Resolve(dialect.DefaultName)
+	if err != nil {
+		b.Fatal(err)
+	}
+	encoded, err := json.Marshal(file)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var parsed NDM2File
+		if err := json.Unmarshal(encoded, &parsed); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := generateSQLFromNDM2File(parsed, d); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateSQLStreamed(b *testing.B) {
+	file := syntheticNDM2File(benchTableCount, benchFieldsPerTable)
+	d, err := dialect.Resolve(dialect.DefaultName)
+	if err != nil {
+		b.Fatal(err)
+	}
+	encoded, err := json.Marshal(file)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out bytes.Buffer
+		if err := generateSQLStreamed(bytes.NewReader(encoded), &out, d); err != nil {
+			b.Fatal(err)
+		}
+	}
+}